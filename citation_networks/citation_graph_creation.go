@@ -44,8 +44,9 @@ func Create_graph(file_name string) *model.NewGraph {
 	}
 
 	for _, node := range g.Nodes {
-		if neighbors, ok := node.Attributes["neighbors"].([]string); ok {
-			for _, neighbor := range neighbors {
+		// after decoding, the neighbors are a []interface{} of strings
+		if neighbors, err := model.AttributeStringList.Coerce(node.Attributes["neighbors"]); err == nil {
+			for _, neighbor := range neighbors.([]string) {
 				g.AddEdge(model.NewEdge{
 					First_node:  node,
 					Second_node: g.Nodes[neighbor],
@@ -53,7 +54,7 @@ func Create_graph(file_name string) *model.NewGraph {
 				})
 			}
 		}
-		delete(node.Attributes, "neighbor")
+		delete(node.Attributes, "neighbors")
 	}
 
 	fmt.Println("Graph creation successful!")
@@ -13,9 +13,9 @@ import (
 )
 
 type IGraphFormatReader interface {
-	Read(reader io.Reader) model.UndirectedGraph
-	ReadFromFile(filename string) model.UndirectedGraph
-	AddNodesToGraph(g *model.UndirectedGraph, nodes []model.Node)
+	Read(reader io.Reader) (*model.UndirectedGraph, error)
+	ReadFromFile(filename string) (*model.UndirectedGraph, error)
+	AddNodesToGraph(g model.GraphBuilder, nodes []model.Node)
 }
 
 type GraphFormatReader struct {
@@ -25,9 +25,68 @@ type GraphFormatReader struct {
 type AdjacencyListReader struct{ GraphFormatReader } // DONE
 type EdgeListReader struct{ GraphFormatReader }      // DONE
 
+// Read reads the graph as an UndirectedGraph that keeps repeated edges and
+// self-loops of the input. To drop them, pass model.NewSimpleGraph() to
+// ReadInto.
 func (strategy *GraphFormatReader) Read(reader io.Reader) (*model.UndirectedGraph, error) {
 	ng := &model.UndirectedGraph{}
+	if err := strategy.ReadInto(reader, ng); err != nil {
+		return nil, err
+	}
+	return ng, nil
+}
+
+// ReadDirected reads the graph as a DirectedGraph, with edges pointing from the
+// first node of each line to the following ones.
+func (strategy *GraphFormatReader) ReadDirected(reader io.Reader) (*model.DirectedGraph, error) {
+	ng := &model.DirectedGraph{}
+	if err := strategy.ReadInto(reader, ng); err != nil {
+		return nil, err
+	}
+	return ng, nil
+}
+
+// ReadCSR reads the graph straight into a CSRGraph, without building an
+// UndirectedGraph first.
+func (strategy *GraphFormatReader) ReadCSR(reader io.Reader) (*model.CSRGraph, error) {
+	builder := model.NewCSRBuilder()
+	if err := strategy.ReadInto(reader, builder); err != nil {
+		return nil, err
+	}
+	return builder.Build(), nil
+}
+
+// ReadInto reads every line of the reader and adds its nodes and edges to g.
+func (strategy *GraphFormatReader) ReadInto(reader io.Reader, g model.GraphBuilder) error {
+	return strategy.readLines(reader, func(line []string) error {
+		nodes, err := parseLine(line)
+		if err != nil {
+			return err
+		}
+		strategy.IGraphFormatReader.AddNodesToGraph(g, nodes)
+		return nil
+	})
+}
+
+// ReadKeyed reads a graph whose node IDs are arbitrary strings, such as URLs,
+// into a KeyedGraph. Lines are interpreted by the same strategies as Read.
+func (strategy *GraphFormatReader) ReadKeyed(reader io.Reader) (*model.KeyedGraph[string], error) {
+	builder := &keyedBuilder{graph: &model.KeyedGraph[string]{}, ids: map[string]model.Node{}}
+	err := strategy.readLines(reader, func(line []string) error {
+		nodes := make([]model.Node, len(line))
+		for i, name := range line {
+			nodes[i] = builder.intern(name)
+		}
+		strategy.IGraphFormatReader.AddNodesToGraph(builder, nodes)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return builder.graph, nil
+}
 
+func (strategy *GraphFormatReader) readLines(reader io.Reader, handle func(line []string) error) error {
 	csvReader := csv.NewReader(reader)
 	lineCount := 0
 	for {
@@ -36,14 +95,41 @@ func (strategy *GraphFormatReader) Read(reader io.Reader) (*model.UndirectedGrap
 			if errors.Is(err, io.EOF) {
 				break
 			}
-			return nil, fmt.Errorf("error reading csv: %w", err)
+			return fmt.Errorf("error reading csv: %w", err)
 		}
 		slog.Info(fmt.Sprintf("read: %+v", read))
-		nodes := lineToList(read)
-		strategy.IGraphFormatReader.AddNodesToGraph(ng, nodes)
+		if err := handle(read); err != nil {
+			return fmt.Errorf("error on line %d: %w", lineCount+1, err)
+		}
 		lineCount++
 	}
-	return ng, nil
+	return nil
+}
+
+// keyedBuilder lets the line strategies, which work on model.Node, fill a
+// KeyedGraph of strings. Every distinct string is interned as a Node.
+type keyedBuilder struct {
+	graph *model.KeyedGraph[string]
+	ids   map[string]model.Node
+	names []string
+}
+
+func (b *keyedBuilder) intern(name string) model.Node {
+	if id, ok := b.ids[name]; ok {
+		return id
+	}
+	id := model.Node(len(b.names))
+	b.ids[name] = id
+	b.names = append(b.names, name)
+	return id
+}
+
+func (b *keyedBuilder) AddNode(node model.Node) {
+	b.graph.AddNode(b.names[node])
+}
+
+func (b *keyedBuilder) AddEdge(edge model.Edge) {
+	b.graph.AddEdge(model.KeyedEdge[string]{Node1: b.names[edge.Node1], Node2: b.names[edge.Node2]})
 }
 
 func (strategy *GraphFormatReader) ReadFromFile(filename string) (*model.UndirectedGraph, error) {
@@ -64,11 +150,14 @@ func (strategy *GraphFormatReader) ReadFromFile(filename string) (*model.Undirec
 	return ng, nil
 }
 
-func (a *AdjacencyListReader) AddNodesToGraph(g *model.UndirectedGraph, nodes []model.Node) {
-	g.AddEdgesFromIntEdgeList(nodes[0], nodes[1:])
+func (a *AdjacencyListReader) AddNodesToGraph(g model.GraphBuilder, nodes []model.Node) {
+	g.AddNode(nodes[0])
+	for _, node := range nodes[1:] {
+		g.AddEdge(model.Edge{Node1: nodes[0], Node2: node})
+	}
 }
 
-func (a *EdgeListReader) AddNodesToGraph(g *model.UndirectedGraph, nodes []model.Node) {
+func (a *EdgeListReader) AddNodesToGraph(g model.GraphBuilder, nodes []model.Node) {
 	g.AddEdge(model.Edge{Node1: nodes[0], Node2: nodes[1]})
 }
 
@@ -85,13 +174,23 @@ Panics:
 - If any string in the input slice cannot be converted to an integer, a panic with the corresponding error is triggered.
 */
 func lineToList(values []string) (integers []model.Node) {
-	integers = make([]model.Node, len(values))
+	integers, err := parseLine(values)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// parseLine is like lineToList, but returns an error for values that are not
+// integers instead of panicking. Graphs with such IDs can be read with ReadKeyed.
+func parseLine(values []string) ([]model.Node, error) {
+	integers := make([]model.Node, len(values))
 	for index, value := range values {
 		valueInt, err := strconv.Atoi(value)
 		if err != nil {
-			panic(err)
+			return nil, fmt.Errorf("node ID %q is not an integer: %w", value, err)
 		}
 		integers[index] = model.Node(valueInt)
 	}
-	return
+	return integers, nil
 }
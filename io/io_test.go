@@ -1,6 +1,7 @@
 package io
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -23,3 +24,34 @@ func TestLinetoList(t *testing.T) {
 		t.Errorf("Expected 3, got %d", list[2])
 	}
 }
+
+func TestReadCSR(t *testing.T) {
+	reader := GraphFormatReader{IGraphFormatReader: &EdgeListReader{}}
+	csr, err := reader.ReadCSR(strings.NewReader("3,1\n1,2\n2,3\n3,4\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if csr.NumberOfNodes() != 4 || csr.NumberOfEdges() != 4 {
+		t.Errorf("Expected 4 nodes and 4 edges, got %d and %d", csr.NumberOfNodes(), csr.NumberOfEdges())
+	}
+	if csr.NodeAt(0) != 1 || csr.NodeDegree(3) != 3 {
+		t.Errorf("Expected node 1 first and degree 3 for node 3, got %d and %d", csr.NodeAt(0), csr.NodeDegree(3))
+	}
+}
+
+func TestReadKeyed(t *testing.T) {
+	reader := GraphFormatReader{IGraphFormatReader: &AdjacencyListReader{}}
+	g, err := reader.ReadKeyed(strings.NewReader("https://a.org,https://b.org,https://c.org\nhttps://b.org,https://c.org,https://d.org\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if g.NumberOfNodes() != 4 || g.NumberOfEdges() != 4 || g.NodeDegree("https://b.org") != 3 {
+		t.Errorf("Unexpected graph %v", g)
+	}
+
+	// Test case 2: Non-integer IDs are an error, not a panic, for Read
+	if _, err := reader.Read(strings.NewReader("a,b,c\n")); err == nil {
+		t.Errorf("Expected an error for non-integer node IDs")
+	}
+}
@@ -0,0 +1,362 @@
+package model
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Matrix is a dense row-major matrix of float32, e.g. one text embedding per
+// row. Row i holds Data[i*Cols : (i+1)*Cols].
+type Matrix struct {
+	Rows int
+	Cols int
+	Data []float32
+}
+
+// NewMatrix returns a zero matrix with the given shape.
+func NewMatrix(rows, cols int) *Matrix {
+	return &Matrix{Rows: rows, Cols: cols, Data: make([]float32, rows*cols)}
+}
+
+// Row returns row i. The slice shares memory with the matrix.
+func (m *Matrix) Row(i int) []float32 {
+	return m.Data[i*m.Cols : (i+1)*m.Cols]
+}
+
+// CosineSimilarity returns the cosine similarity of rows i and j, or 0 if one
+// of them is a zero vector.
+func (m *Matrix) CosineSimilarity(i, j int) float32 {
+	return cosine(m.Row(i), m.Row(j))
+}
+
+func cosine(a, b []float32) float32 {
+	var dot, normA, normB float64
+	for k := range a {
+		dot += float64(a[k]) * float64(b[k])
+		normA += float64(a[k]) * float64(a[k])
+		normB += float64(b[k]) * float64(b[k])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return float32(dot / math.Sqrt(normA*normB))
+}
+
+// CategoricalColumn stores a list of categories, such as keywords, for every
+// row. The categories are dictionary encoded: row i holds the codes
+// codes[offsets[i]:offsets[i+1]], which index into Levels.
+type CategoricalColumn struct {
+	Levels  []string
+	offsets []int32
+	codes   []int32
+}
+
+// NewCategoricalColumn encodes one list of categories per row. Levels are
+// numbered in the order in which they first appear.
+func NewCategoricalColumn(values [][]string) *CategoricalColumn {
+	column := &CategoricalColumn{offsets: make([]int32, 1, len(values)+1)}
+	levels := map[string]int32{}
+	for _, row := range values {
+		for _, value := range row {
+			code, ok := levels[value]
+			if !ok {
+				code = int32(len(column.Levels))
+				levels[value] = code
+				column.Levels = append(column.Levels, value)
+			}
+			column.codes = append(column.codes, code)
+		}
+		column.offsets = append(column.offsets, int32(len(column.codes)))
+	}
+	return column
+}
+
+// Len returns the number of rows of the column.
+func (c *CategoricalColumn) Len() int {
+	return len(c.offsets) - 1
+}
+
+// Codes returns the level codes of row i. The slice shares memory with the column.
+func (c *CategoricalColumn) Codes(i int) []int32 {
+	return c.codes[c.offsets[i]:c.offsets[i+1]]
+}
+
+// Values returns the categories of row i.
+func (c *CategoricalColumn) Values(i int) []string {
+	codes := c.Codes(i)
+	values := make([]string, len(codes))
+	for k, code := range codes {
+		values[k] = c.Levels[code]
+	}
+	return values
+}
+
+// MultiHot returns a matrix with one column per level, where entry (i, l) is 1
+// if row i has level l.
+func (c *CategoricalColumn) MultiHot() *Matrix {
+	m := NewMatrix(c.Len(), len(c.Levels))
+	for i := 0; i < c.Len(); i++ {
+		row := m.Row(i)
+		for _, code := range c.Codes(i) {
+			row[code] = 1
+		}
+	}
+	return m
+}
+
+// AttributeTable stores node features column-wise, with one row per node. It
+// holds dense float32 matrices, e.g. for embeddings, and categorical columns,
+// e.g. for keywords. Rows are addressed by node index, so feature export and
+// similarity search work on contiguous arrays instead of attribute maps.
+type AttributeTable[K comparable] struct {
+	ids          []K
+	index        map[K]int
+	matrices     map[string]*Matrix
+	categoricals map[string]*CategoricalColumn
+}
+
+// NewAttributeTable returns an empty table with one row per node, in the
+// order of ids.
+func NewAttributeTable[K comparable](ids []K) *AttributeTable[K] {
+	index := make(map[K]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	return &AttributeTable[K]{
+		ids:          append([]K(nil), ids...),
+		index:        index,
+		matrices:     map[string]*Matrix{},
+		categoricals: map[string]*CategoricalColumn{},
+	}
+}
+
+// NewCSRAttributeTable returns an empty table whose row indices are the node
+// indices of the CSRGraph.
+func NewCSRAttributeTable(g *CSRGraph) *AttributeTable[Node] {
+	return NewAttributeTable(g.ids)
+}
+
+// NodeAttributeTable extracts node attributes of a NewGraph into a table with
+// one row per node, sorted by ID.
+//
+// Parameters:
+//   - g: The graph.
+//   - vectors: Attributes holding []float64 vectors, e.g. "text_embedding". Each becomes a
+//     matrix; nodes without the attribute get a zero row.
+//   - categoricals: Attributes holding string lists, e.g. "keywords". Each becomes a
+//     categorical column; nodes without the attribute get an empty list.
+//
+// Returns:
+//
+//	The table, or an error if a value has the wrong type or vectors differ in length.
+func NodeAttributeTable(g *NewGraph, vectors, categoricals []string) (*AttributeTable[string], error) {
+	ids := make([]string, 0, len(g.Nodes))
+	for id := range g.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	table := NewAttributeTable(ids)
+
+	for _, name := range vectors {
+		rows := make([][]float64, len(ids))
+		cols := -1
+		for i, id := range ids {
+			value, ok := g.Nodes[id].Attributes[name]
+			if !ok {
+				continue
+			}
+			vector, err := AttributeVector.Coerce(value)
+			if err != nil {
+				return nil, fmt.Errorf("node %s: %q: %w", id, name, err)
+			}
+			rows[i] = vector.([]float64)
+			if cols >= 0 && len(rows[i]) != cols {
+				return nil, fmt.Errorf("node %s: %q has length %d instead of %d", id, name, len(rows[i]), cols)
+			}
+			cols = len(rows[i])
+		}
+		m := NewMatrix(len(ids), max(cols, 0))
+		for i, row := range rows {
+			for k, x := range row {
+				m.Data[i*m.Cols+k] = float32(x)
+			}
+		}
+		table.matrices[name] = m
+	}
+
+	for _, name := range categoricals {
+		values := make([][]string, len(ids))
+		for i, id := range ids {
+			value, ok := g.Nodes[id].Attributes[name]
+			if !ok {
+				continue
+			}
+			list, err := AttributeStringList.Coerce(value)
+			if err != nil {
+				return nil, fmt.Errorf("node %s: %q: %w", id, name, err)
+			}
+			values[i] = list.([]string)
+		}
+		table.categoricals[name] = NewCategoricalColumn(values)
+	}
+	return table, nil
+}
+
+// Len returns the number of rows of the table.
+func (t *AttributeTable[K]) Len() int {
+	return len(t.ids)
+}
+
+// IDs returns the node of every row.
+func (t *AttributeTable[K]) IDs() []K {
+	return append([]K(nil), t.ids...)
+}
+
+// Index returns the row of the node and whether the table contains it.
+func (t *AttributeTable[K]) Index(id K) (int, bool) {
+	i, ok := t.index[id]
+	return i, ok
+}
+
+// SetMatrix stores a matrix column. It must have one row per node.
+func (t *AttributeTable[K]) SetMatrix(name string, m *Matrix) error {
+	if m.Rows != t.Len() || len(m.Data) != m.Rows*m.Cols {
+		return fmt.Errorf("matrix %q has %d rows and %d values, but the table has %d rows", name, m.Rows, len(m.Data), t.Len())
+	}
+	t.matrices[name] = m
+	return nil
+}
+
+// Matrix returns the matrix column with the given name, or nil.
+func (t *AttributeTable[K]) Matrix(name string) *Matrix {
+	return t.matrices[name]
+}
+
+// SetCategorical stores a categorical column. It must have one row per node.
+func (t *AttributeTable[K]) SetCategorical(name string, column *CategoricalColumn) error {
+	if column.Len() != t.Len() {
+		return fmt.Errorf("column %q has %d rows, but the table has %d", name, column.Len(), t.Len())
+	}
+	t.categoricals[name] = column
+	return nil
+}
+
+// Categorical returns the categorical column with the given name, or nil.
+func (t *AttributeTable[K]) Categorical(name string) *CategoricalColumn {
+	return t.categoricals[name]
+}
+
+// Features concatenates the named columns into one feature matrix, e.g. for
+// export to a machine learning framework. Categorical columns are multi-hot
+// encoded.
+func (t *AttributeTable[K]) Features(names ...string) (*Matrix, error) {
+	parts := make([]*Matrix, len(names))
+	cols := 0
+	for k, name := range names {
+		if m, ok := t.matrices[name]; ok {
+			parts[k] = m
+		} else if c, ok := t.categoricals[name]; ok {
+			parts[k] = c.MultiHot()
+		} else {
+			return nil, fmt.Errorf("the table has no column %q", name)
+		}
+		cols += parts[k].Cols
+	}
+
+	features := NewMatrix(t.Len(), cols)
+	for i := 0; i < t.Len(); i++ {
+		row := features.Row(i)
+		offset := 0
+		for _, part := range parts {
+			copy(row[offset:], part.Row(i))
+			offset += part.Cols
+		}
+	}
+	return features, nil
+}
+
+// SimilarNode is a result of a similarity search.
+type SimilarNode[K comparable] struct {
+	ID         K
+	Similarity float32
+}
+
+// MostSimilar returns the k nodes whose rows in the matrix column are most
+// similar to the row of id by cosine similarity, most similar first. The node
+// itself is excluded.
+func (t *AttributeTable[K]) MostSimilar(name string, id K, k int) ([]SimilarNode[K], error) {
+	m := t.matrices[name]
+	if m == nil {
+		return nil, fmt.Errorf("the table has no matrix %q", name)
+	}
+	i, ok := t.index[id]
+	if !ok {
+		return nil, fmt.Errorf("the table has no row for %v", id)
+	}
+	return t.search(m, m.Row(i), k, i), nil
+}
+
+// MostSimilarTo returns the k nodes whose rows in the matrix column are most
+// similar to the query vector by cosine similarity, most similar first.
+func (t *AttributeTable[K]) MostSimilarTo(name string, query []float32, k int) ([]SimilarNode[K], error) {
+	m := t.matrices[name]
+	if m == nil {
+		return nil, fmt.Errorf("the table has no matrix %q", name)
+	}
+	if len(query) != m.Cols {
+		return nil, fmt.Errorf("query has length %d, but matrix %q has %d columns", len(query), name, m.Cols)
+	}
+	return t.search(m, query, k, -1), nil
+}
+
+// search keeps the k best rows in a min-heap, so it runs in O(n log k).
+func (t *AttributeTable[K]) search(m *Matrix, query []float32, k int, skip int) []SimilarNode[K] {
+	best := &similarityHeap{}
+	for i := 0; i < m.Rows; i++ {
+		if i == skip {
+			continue
+		}
+		candidate := scoredRow{row: i, similarity: cosine(query, m.Row(i))}
+		if best.Len() < k {
+			heap.Push(best, candidate)
+		} else if best.Len() > 0 && candidate.better((*best)[0]) {
+			(*best)[0] = candidate
+			heap.Fix(best, 0)
+		}
+	}
+	result := make([]SimilarNode[K], best.Len())
+	for n := len(result) - 1; n >= 0; n-- {
+		top := heap.Pop(best).(scoredRow)
+		result[n] = SimilarNode[K]{ID: t.ids[top.row], Similarity: top.similarity}
+	}
+	return result
+}
+
+type scoredRow struct {
+	row        int
+	similarity float32
+}
+
+// better orders rows by similarity, breaking ties by the lower row.
+func (a scoredRow) better(b scoredRow) bool {
+	if a.similarity != b.similarity {
+		return a.similarity > b.similarity
+	}
+	return a.row < b.row
+}
+
+// similarityHeap is a min-heap with the worst of the best rows on top.
+type similarityHeap []scoredRow
+
+func (h similarityHeap) Len() int           { return len(h) }
+func (h similarityHeap) Less(i, j int) bool { return h[j].better(h[i]) }
+func (h similarityHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *similarityHeap) Push(x any)        { *h = append(*h, x.(scoredRow)) }
+func (h *similarityHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func paperGraph() NewGraph {
+	g := BasicGraph()
+	papers := map[string]map[string]interface{}{
+		"p1": {"text_embedding": []float64{1, 0}, "keywords": []interface{}{"graphs", "sampling"}},
+		"p2": {"text_embedding": []float64{0.9, 0.1}, "keywords": []string{"graphs"}},
+		"p3": {"text_embedding": []float64{0, 1}},
+		"p4": {"keywords": []string{"embeddings"}},
+	}
+	for id, attributes := range papers {
+		g.AddNode(NewNode{ID: id, Attributes: attributes})
+	}
+	return g
+}
+
+func TestNodeAttributeTable(t *testing.T) {
+	g := paperGraph()
+	table, err := NodeAttributeTable(&g, []string{"text_embedding"}, []string{"keywords"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(table.IDs(), []string{"p1", "p2", "p3", "p4"}) {
+		t.Errorf("Expected rows sorted by ID, but got %v", table.IDs())
+	}
+	embeddings := table.Matrix("text_embedding")
+	if embeddings.Rows != 4 || embeddings.Cols != 2 || !reflect.DeepEqual(embeddings.Row(3), []float32{0, 0}) {
+		t.Errorf("Unexpected embedding matrix %v", embeddings)
+	}
+	keywords := table.Categorical("keywords")
+	if !reflect.DeepEqual(keywords.Values(0), []string{"graphs", "sampling"}) || len(keywords.Values(2)) != 0 || len(keywords.Levels) != 3 {
+		t.Errorf("Unexpected keyword column %v", keywords)
+	}
+
+	features, err := table.Features("text_embedding", "keywords")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if features.Cols != 5 || !reflect.DeepEqual(features.Row(0), []float32{1, 0, 1, 1, 0}) {
+		t.Errorf("Unexpected feature row %v", features.Row(0))
+	}
+
+	// Test case 2: Vectors of different lengths are rejected
+	g.Nodes["p3"].Attributes["text_embedding"] = []float64{0, 1, 2}
+	if _, err := NodeAttributeTable(&g, []string{"text_embedding"}, nil); err == nil {
+		t.Errorf("Expected an error for vectors of different lengths")
+	}
+}
+
+func TestAttributeTable_MostSimilar(t *testing.T) {
+	g := paperGraph()
+	table, _ := NodeAttributeTable(&g, []string{"text_embedding"}, nil)
+
+	neighbors, err := table.MostSimilar("text_embedding", "p1", 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(neighbors) != 2 || neighbors[0].ID != "p2" || neighbors[1].ID != "p3" {
+		t.Errorf("Expected p2 then p3, but got %v", neighbors)
+	}
+
+	neighbors, _ = table.MostSimilarTo("text_embedding", []float32{0, 2}, 1)
+	if len(neighbors) != 1 || neighbors[0].ID != "p3" || neighbors[0].Similarity != 1 {
+		t.Errorf("Expected p3 with similarity 1, but got %v", neighbors)
+	}
+
+	if _, err := table.MostSimilar("missing", "p1", 1); err == nil {
+		t.Errorf("Expected an error for a missing column")
+	}
+	csr := NewCSRAttributeTable(NewCSRGraph(twoCliques()))
+	if err := csr.SetMatrix("x", NewMatrix(3, 1)); err == nil {
+		t.Errorf("Expected an error for a matrix with the wrong number of rows")
+	}
+}
@@ -0,0 +1,340 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// AttributeKind is the type of an attribute declared in an AttributeSchema.
+type AttributeKind int
+
+const (
+	AttributeInt        AttributeKind = iota + 1 // int
+	AttributeFloat                               // float64
+	AttributeString                              // string
+	AttributeBool                                // bool
+	AttributeVector                              // []float64, e.g. embeddings
+	AttributeStringList                          // []string, e.g. keywords
+)
+
+var attributeKindNames = map[AttributeKind]string{
+	AttributeInt:        "int",
+	AttributeFloat:      "float64",
+	AttributeString:     "string",
+	AttributeBool:       "bool",
+	AttributeVector:     "vector",
+	AttributeStringList: "strings",
+}
+
+// ErrUndeclaredAttribute is returned when an attribute that the schema does not
+// declare is set on a graph that has a schema.
+var ErrUndeclaredAttribute = errors.New("attribute is not declared in the schema")
+
+func (k AttributeKind) String() string {
+	if name, ok := attributeKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("AttributeKind(%d)", int(k))
+}
+
+// MarshalText encodes the kind by name, so schemas are readable in JSON.
+func (k AttributeKind) MarshalText() ([]byte, error) {
+	if _, ok := attributeKindNames[k]; !ok {
+		return nil, fmt.Errorf("unknown attribute kind %d", int(k))
+	}
+	return []byte(k.String()), nil
+}
+
+func (k *AttributeKind) UnmarshalText(text []byte) error {
+	for kind, name := range attributeKindNames {
+		if name == string(text) {
+			*k = kind
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown attribute kind %q", text)
+}
+
+// Coerce converts the value to the Go type of the kind: int, float64, string,
+// bool, []float64 or []string. Besides values of that type it accepts the
+// types produced by encoding/json, such as float64 for ints, json.Number and
+// []interface{} for lists. Numbers are only converted when no precision is lost.
+func (k AttributeKind) Coerce(value interface{}) (interface{}, error) {
+	var (
+		result interface{}
+		ok     bool
+	)
+	switch k {
+	case AttributeInt:
+		result, ok = toInt(value)
+	case AttributeFloat:
+		result, ok = toFloat(value)
+	case AttributeString:
+		result, ok = value.(string)
+	case AttributeBool:
+		result, ok = value.(bool)
+	case AttributeVector:
+		result, ok = toVector(value)
+	case AttributeStringList:
+		result, ok = toStringList(value)
+	default:
+		return nil, fmt.Errorf("unknown attribute kind %d", int(k))
+	}
+	if !ok {
+		return nil, fmt.Errorf("cannot use %v (%T) as %s", value, value, k)
+	}
+	return result, nil
+}
+
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return 0, false
+		}
+		return int(v), true
+	case json.Number:
+		i, err := v.Int64()
+		return int(i), err == nil
+	}
+	return 0, false
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func toVector(value interface{}) ([]float64, bool) {
+	switch v := value.(type) {
+	case []float64:
+		return v, true
+	case []float32:
+		vector := make([]float64, len(v))
+		for i, x := range v {
+			vector[i] = float64(x)
+		}
+		return vector, true
+	case []interface{}:
+		vector := make([]float64, len(v))
+		for i, x := range v {
+			f, ok := toFloat(x)
+			if !ok {
+				return nil, false
+			}
+			vector[i] = f
+		}
+		return vector, true
+	}
+	return nil, false
+}
+
+func toStringList(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		list := make([]string, len(v))
+		for i, x := range v {
+			s, ok := x.(string)
+			if !ok {
+				return nil, false
+			}
+			list[i] = s
+		}
+		return list, true
+	}
+	return nil, false
+}
+
+// AttributeSchema declares the kind of every attribute of the nodes or edges
+// of a graph. A nil schema accepts any attribute without conversion.
+type AttributeSchema map[string]AttributeKind
+
+// Coerce validates a single attribute value against the schema and converts it
+// to the declared type.
+func (s AttributeSchema) Coerce(name string, value interface{}) (interface{}, error) {
+	if s == nil {
+		return value, nil
+	}
+	kind, ok := s[name]
+	if !ok {
+		return nil, fmt.Errorf("%q: %w", name, ErrUndeclaredAttribute)
+	}
+	coerced, err := kind.Coerce(value)
+	if err != nil {
+		return nil, fmt.Errorf("%q: %w", name, err)
+	}
+	return coerced, nil
+}
+
+// coerceAll converts all attributes in place, returning the first error.
+func (s AttributeSchema) coerceAll(attributes map[string]interface{}) error {
+	for name, value := range attributes {
+		coerced, err := s.Coerce(name, value)
+		if err != nil {
+			return err
+		}
+		attributes[name] = coerced
+	}
+	return nil
+}
+
+// DeclareNodeAttribute adds an attribute to the node schema of the graph.
+func (g *NewGraph) DeclareNodeAttribute(name string, kind AttributeKind) {
+	if g.NodeSchema == nil {
+		g.NodeSchema = AttributeSchema{}
+	}
+	g.NodeSchema[name] = kind
+}
+
+// DeclareEdgeAttribute adds an attribute to the edge schema of the graph.
+func (g *NewGraph) DeclareEdgeAttribute(name string, kind AttributeKind) {
+	if g.EdgeSchema == nil {
+		g.EdgeSchema = AttributeSchema{}
+	}
+	g.EdgeSchema[name] = kind
+}
+
+// SetNodeAttribute validates the value against the node schema and stores it,
+// converted to the declared type, on the node with the given ID.
+func (g *NewGraph) SetNodeAttribute(id, name string, value interface{}) error {
+	attributes := g.NodeAttributes(id)
+	if attributes == nil {
+		return fmt.Errorf("this graph does not have node with id: %s", id)
+	}
+	coerced, err := g.NodeSchema.Coerce(name, value)
+	if err != nil {
+		return err
+	}
+	attributes[name] = coerced
+	return nil
+}
+
+// SetEdgeAttribute validates the value against the edge schema and stores it,
+// converted to the declared type, on the edge between u and v.
+func (g *NewGraph) SetEdgeAttribute(u, v, name string, value interface{}) error {
+	attributes := g.EdgeAttributes(u, v)
+	if attributes == nil {
+		return fmt.Errorf("this graph does not have edge between %s and %s", u, v)
+	}
+	coerced, err := g.EdgeSchema.Coerce(name, value)
+	if err != nil {
+		return err
+	}
+	attributes[name] = coerced
+	return nil
+}
+
+// ValidateAttributes checks the attributes of all nodes and edges against the
+// schemas and converts them to the declared types.
+func (g *NewGraph) ValidateAttributes() error {
+	for id, node := range g.Nodes {
+		if err := g.NodeSchema.coerceAll(node.Attributes); err != nil {
+			return fmt.Errorf("node %s: %w", id, err)
+		}
+	}
+	for key, edge := range g.Edges {
+		if err := g.EdgeSchema.coerceAll(edge.Attributes); err != nil {
+			return fmt.Errorf("edge %d: %w", key, err)
+		}
+		for _, node := range []NewNode{edge.First_node, edge.Second_node} {
+			if err := g.NodeSchema.coerceAll(node.Attributes); err != nil {
+				return fmt.Errorf("node %s of edge %d: %w", node.ID, key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// NodeAttribute returns the attribute of the node with the given ID as a T,
+// and whether it exists with that type.
+func NodeAttribute[T any](g *NewGraph, id, name string) (T, bool) {
+	value, ok := g.Nodes[id].Attributes[name].(T)
+	return value, ok
+}
+
+// EdgeAttribute returns the attribute of the edge between u and v as a T, and
+// whether it exists with that type.
+func EdgeAttribute[T any](g *NewGraph, u, v, name string) (T, bool) {
+	value, ok := g.EdgeAttributes(u, v)[name].(T)
+	return value, ok
+}
+
+// UnmarshalJSON decodes a graph written by ToJSON, keeping the keys of removed
+// edges out of use. Numbers are decoded without
+// loss of precision, and attributes are converted to the types declared in the
+// schemas, so e.g. string lists come back as []string instead of
+// []interface{}. Attributes of graphs without a schema decode as with
+// encoding/json.
+func (g *NewGraph) UnmarshalJSON(data []byte) error {
+	// graphJSON has the fields of NewGraph but not its methods, which avoids
+	// calling UnmarshalJSON recursively
+	type graphJSON NewGraph
+	var decoded struct {
+		graphJSON
+		NextEdgeID int
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return err
+	}
+	*g = NewGraph(decoded.graphJSON)
+	g.nextEdgeID = decoded.NextEdgeID
+
+	plainNumbers := func(schema AttributeSchema, attributes map[string]interface{}) {
+		for name, value := range attributes {
+			if _, declared := schema[name]; !declared {
+				attributes[name] = withoutJSONNumbers(value)
+			}
+		}
+	}
+	for _, node := range g.Nodes {
+		plainNumbers(g.NodeSchema, node.Attributes)
+	}
+	for _, edge := range g.Edges {
+		plainNumbers(g.EdgeSchema, edge.Attributes)
+		plainNumbers(g.NodeSchema, edge.First_node.Attributes)
+		plainNumbers(g.NodeSchema, edge.Second_node.Attributes)
+	}
+	return g.ValidateAttributes()
+}
+
+// withoutJSONNumbers replaces json.Number by float64, as encoding/json decodes
+// numbers without UseNumber.
+func withoutJSONNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case []interface{}:
+		for i, x := range v {
+			v[i] = withoutJSONNumbers(x)
+		}
+	case map[string]interface{}:
+		for key, x := range v {
+			v[key] = withoutJSONNumbers(x)
+		}
+	}
+	return value
+}
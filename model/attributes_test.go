@@ -0,0 +1,108 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func schemaGraph() NewGraph {
+	g := BasicGraph()
+	g.DeclareNodeAttribute("year", AttributeInt)
+	g.DeclareNodeAttribute("score", AttributeFloat)
+	g.DeclareNodeAttribute("title", AttributeString)
+	g.DeclareNodeAttribute("open", AttributeBool)
+	g.DeclareNodeAttribute("embedding", AttributeVector)
+	g.DeclareNodeAttribute("keywords", AttributeStringList)
+	g.DeclareEdgeAttribute("weight", AttributeFloat)
+	g.AddEdgeBetween("p1", "p2")
+	return g
+}
+
+func TestNewGraph_SetAttribute(t *testing.T) {
+	g := schemaGraph()
+
+	// Test case 1: Values are converted to the declared types
+	values := map[string]interface{}{
+		"year":      2024.0,
+		"score":     3,
+		"title":     "Sampling from large graphs",
+		"open":      true,
+		"embedding": []float32{0.5, 1},
+		"keywords":  []interface{}{"graphs", "sampling"},
+	}
+	for name, value := range values {
+		if err := g.SetNodeAttribute("p1", name, value); err != nil {
+			t.Errorf("Unexpected error for %s: %v", name, err)
+		}
+	}
+	if year, ok := NodeAttribute[int](&g, "p1", "year"); !ok || year != 2024 {
+		t.Errorf("Expected year 2024, but got %v", year)
+	}
+	if keywords, ok := NodeAttribute[[]string](&g, "p1", "keywords"); !ok || len(keywords) != 2 {
+		t.Errorf("Expected 2 keywords, but got %v", keywords)
+	}
+	if err := g.SetEdgeAttribute("p2", "p1", "weight", 2); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if weight, ok := EdgeAttribute[float64](&g, "p1", "p2", "weight"); !ok || weight != 2 {
+		t.Errorf("Expected weight 2, but got %v", weight)
+	}
+
+	// Test case 2: Invalid values are rejected
+	if err := g.SetNodeAttribute("p1", "year", 2024.5); err == nil {
+		t.Errorf("Expected an error for a fractional year")
+	}
+	if err := g.SetNodeAttribute("p1", "keywords", []interface{}{"graphs", 1}); err == nil {
+		t.Errorf("Expected an error for a non-string keyword")
+	}
+	if err := g.SetNodeAttribute("p1", "author", "x"); !errors.Is(err, ErrUndeclaredAttribute) {
+		t.Errorf("Expected ErrUndeclaredAttribute, but got %v", err)
+	}
+	if err := g.SetNodeAttribute("p3", "year", 1); err == nil {
+		t.Errorf("Expected an error for a missing node")
+	}
+}
+
+func TestNewGraph_JSONRoundTrip(t *testing.T) {
+	g := schemaGraph()
+	g.SetNodeAttribute("p1", "year", 1<<60)
+	g.SetNodeAttribute("p1", "keywords", []string{"graphs"})
+	g.SetNodeAttribute("p2", "embedding", []float64{0.25, 0.5})
+	g.SetEdgeAttribute("p1", "p2", "weight", 0.75)
+
+	data, err := g.ToJSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded NewGraph
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded.NodeSchema, g.NodeSchema) {
+		t.Errorf("Expected schema %v, but got %v", g.NodeSchema, decoded.NodeSchema)
+	}
+	for id, node := range g.Nodes {
+		if !reflect.DeepEqual(decoded.Nodes[id].Attributes, node.Attributes) {
+			t.Errorf("Expected attributes %v, but got %v", node.Attributes, decoded.Nodes[id].Attributes)
+		}
+	}
+	if weight, ok := EdgeAttribute[float64](&decoded, "p1", "p2", "weight"); !ok || weight != 0.75 {
+		t.Errorf("Expected weight 0.75, but got %v", weight)
+	}
+
+	// Test case 2: Graphs without a schema decode as before
+	plain := BasicGraph()
+	plain.AddNode(NewNode{ID: "a", Attributes: map[string]interface{}{"count": 3, "tags": []string{"x"}}})
+	data, _ = plain.ToJSON()
+	var decodedPlain NewGraph
+	if err := json.Unmarshal(data, &decodedPlain); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{"count": 3.0, "tags": []interface{}{"x"}}
+	if !reflect.DeepEqual(decodedPlain.Nodes["a"].Attributes, expected) {
+		t.Errorf("Expected %v, but got %v", expected, decodedPlain.Nodes["a"].Attributes)
+	}
+}
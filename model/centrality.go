@@ -0,0 +1,435 @@
+package model
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// CentralityOptions configures the centrality measures. The zero value gives
+// unweighted, unnormalised, exact results computed with one goroutine per CPU.
+type CentralityOptions[K comparable] struct {
+	// Normalized scales the results to be comparable across graphs of
+	// different sizes, as described for each measure.
+	Normalized bool
+	// Pivots approximates betweenness from shortest paths starting at only that
+	// many randomly chosen nodes, scaled up to the whole graph, or 0 to use all
+	// nodes.
+	Pivots int
+	// Seed seeds the choice of pivots. The same seed picks the same pivots
+	// when NodeIDs lists the nodes in the same order.
+	Seed int64
+	// Workers is the number of goroutines, or 0 to use one per CPU.
+	Workers int
+	// Weight gives the non-negative length of every edge, or nil to measure
+	// distances in number of edges. The measures return an error for negative
+	// lengths.
+	Weight WeightFunc[K]
+}
+
+func (o CentralityOptions[K]) workers() int {
+	if o.Workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return o.Workers
+}
+
+// indexedAdjacency is a snapshot of a graph with nodes numbered in the order
+// of NodeIDs, so that the goroutines of the parallel algorithms only read
+// plain slices.
+type indexedAdjacency[K comparable] struct {
+	nodes     []K
+	neighbors [][]int
+	weights   [][]float64
+	directed  bool
+}
+
+func newIndexedAdjacency[K comparable](g GraphView[K], weight WeightFunc[K]) *indexedAdjacency[K] {
+	nodes := g.NodeIDs()
+	index := make(map[K]int, len(nodes))
+	for i, node := range nodes {
+		index[node] = i
+	}
+	a := &indexedAdjacency[K]{
+		nodes:     nodes,
+		neighbors: make([][]int, len(nodes)),
+		weights:   make([][]float64, len(nodes)),
+		directed:  g.IsDirected(),
+	}
+	for i, node := range nodes {
+		for _, neighbor := range g.NeighborIDs(node) {
+			a.neighbors[i] = append(a.neighbors[i], index[neighbor])
+			w := 1.0
+			if weight != nil {
+				w = weight(node, neighbor)
+			}
+			a.weights[i] = append(a.weights[i], w)
+		}
+	}
+	return a
+}
+
+// checkNonNegative returns an error for the first edge with a negative weight,
+// as the shortest-path searches would return wrong distances.
+func (a *indexedAdjacency[K]) checkNonNegative() error {
+	for u, weights := range a.weights {
+		for k, w := range weights {
+			if w < 0 {
+				return fmt.Errorf("edge from %v to %v has negative weight %v", a.nodes[u], a.nodes[a.neighbors[u][k]], w)
+			}
+		}
+	}
+	return nil
+}
+
+// shortestPathDAG holds the result of a single-source search as used by
+// Brandes' algorithm: the nodes in order of non-decreasing distance, their
+// distances, the number of shortest paths to them and the edges reaching them
+// on those paths. Parallel edges give distinct paths.
+type shortestPathDAG struct {
+	order        []int
+	distances    []float64
+	paths        []float64
+	predecessors [][]dagEdge
+}
+
+// dagEdge is the k-th edge leaving node v in an indexedAdjacency.
+type dagEdge struct {
+	v, k int
+}
+
+func (a *indexedAdjacency[K]) search(source int, weighted bool) *shortestPathDAG {
+	n := len(a.nodes)
+	dag := &shortestPathDAG{
+		distances:    make([]float64, n),
+		paths:        make([]float64, n),
+		predecessors: make([][]dagEdge, n),
+	}
+	for i := range dag.distances {
+		dag.distances[i] = math.Inf(1)
+	}
+	dag.distances[source] = 0
+	dag.paths[source] = 1
+
+	if !weighted {
+		dag.order = append(dag.order, source)
+		for head := 0; head < len(dag.order); head++ {
+			v := dag.order[head]
+			for k, w := range a.neighbors[v] {
+				if math.IsInf(dag.distances[w], 1) {
+					dag.distances[w] = dag.distances[v] + 1
+					dag.order = append(dag.order, w)
+				}
+				if dag.distances[w] == dag.distances[v]+1 {
+					dag.paths[w] += dag.paths[v]
+					dag.predecessors[w] = append(dag.predecessors[w], dagEdge{v, k})
+				}
+			}
+		}
+		return dag
+	}
+
+	settled := make([]bool, n)
+	queue := &searchQueue[int]{{node: source}}
+	for queue.Len() > 0 {
+		v := heap.Pop(queue).(searchItem[int]).node
+		if settled[v] {
+			continue
+		}
+		settled[v] = true
+		dag.order = append(dag.order, v)
+		for k, w := range a.neighbors[v] {
+			distance := dag.distances[v] + a.weights[v][k]
+			switch {
+			case distance < dag.distances[w]:
+				dag.distances[w] = distance
+				dag.paths[w] = dag.paths[v]
+				dag.predecessors[w] = append(dag.predecessors[w][:0], dagEdge{v, k})
+				heap.Push(queue, searchItem[int]{node: w, priority: distance})
+			case distance == dag.distances[w] && !settled[w]:
+				dag.paths[w] += dag.paths[v]
+				dag.predecessors[w] = append(dag.predecessors[w], dagEdge{v, k})
+			}
+		}
+	}
+	return dag
+}
+
+// forEachSource runs visit for every source on a pool of goroutines. Every
+// worker gets its own index, so it can accumulate into its own buffers.
+func forEachSource(sources []int, workers int, visit func(worker, source int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for source := range jobs {
+				visit(worker, source)
+			}
+		}(w)
+	}
+	for _, source := range sources {
+		jobs <- source
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func allIndices(n int) []int {
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
+
+// DegreeCentrality returns the degree of every node divided by n-1, the
+// largest possible degree in a simple graph. In directed graphs, edges in both
+// directions count.
+func DegreeCentrality[K comparable](g GraphView[K]) map[K]float64 {
+	adjacency := undirectedAdjacency(g)
+	nodes := g.NodeIDs()
+	centrality := make(map[K]float64, len(nodes))
+	for _, node := range nodes {
+		centrality[node] = float64(len(adjacency(node)))
+		if len(nodes) > 1 {
+			centrality[node] /= float64(len(nodes) - 1)
+		}
+	}
+	return centrality
+}
+
+// distanceCentrality computes a centrality from the distances from every node
+// to the nodes it can reach.
+func distanceCentrality[K comparable](g GraphView[K], options CentralityOptions[K], score func(distances []float64) float64) (map[K]float64, error) {
+	a := newIndexedAdjacency(g, options.Weight)
+	if err := a.checkNonNegative(); err != nil {
+		return nil, err
+	}
+	scores := make([]float64, len(a.nodes))
+	forEachSource(allIndices(len(a.nodes)), options.workers(), func(_, source int) {
+		scores[source] = score(a.search(source, options.Weight != nil).distances)
+	})
+	centrality := make(map[K]float64, len(a.nodes))
+	for i, node := range a.nodes {
+		centrality[node] = scores[i]
+	}
+	return centrality, nil
+}
+
+/*
+ClosenessCentrality returns the closeness of every node, the reciprocal of the mean distance from it to
+the nodes it can reach.
+
+Parameters:
+- g: The graph. In directed graphs, distances along outgoing edges are used.
+- options: The edge weights and the number of workers. Normalized multiplies the closeness of a node
+that reaches r-1 other nodes by (r-1)/(n-1), so nodes in small components do not score highly.
+
+Returns:
+- map[K]float64: The closeness of every node, 0 for nodes that reach no other node.
+- error: An error if an edge has a negative weight.
+
+References:
+  - Wasserman, S., & Faust, K. (1994). Social Network Analysis: Methods and Applications. Cambridge University Press.
+*/
+func ClosenessCentrality[K comparable](g GraphView[K], options CentralityOptions[K]) (map[K]float64, error) {
+	n := g.NumberOfNodes()
+	return distanceCentrality(g, options, func(distances []float64) float64 {
+		total, reached := 0.0, 0
+		for _, distance := range distances {
+			if distance > 0 && !math.IsInf(distance, 1) {
+				total += distance
+				reached++
+			}
+		}
+		if total == 0 {
+			return 0
+		}
+		closeness := float64(reached) / total
+		if options.Normalized {
+			closeness *= float64(reached) / float64(n-1)
+		}
+		return closeness
+	})
+}
+
+/*
+HarmonicCentrality returns the harmonic centrality of every node, the sum of the reciprocal distances
+from it to all other nodes. Unlike closeness, it is well defined in disconnected graphs.
+
+Parameters:
+- g: The graph. In directed graphs, distances along outgoing edges are used.
+- options: The edge weights and the number of workers. Normalized divides the sums by n-1.
+
+Returns:
+- map[K]float64: The harmonic centrality of every node.
+- error: An error if an edge has a negative weight.
+
+References:
+  - Boldi, P., & Vigna, S. (2014). Axioms for centrality. Internet Mathematics, 10(3-4), 222-262.
+*/
+func HarmonicCentrality[K comparable](g GraphView[K], options CentralityOptions[K]) (map[K]float64, error) {
+	n := g.NumberOfNodes()
+	return distanceCentrality(g, options, func(distances []float64) float64 {
+		harmonic := 0.0
+		for _, distance := range distances {
+			if distance > 0 {
+				harmonic += 1 / distance
+			}
+		}
+		if options.Normalized && n > 1 {
+			harmonic /= float64(n - 1)
+		}
+		return harmonic
+	})
+}
+
+// brandes accumulates the pair dependencies of Brandes' algorithm on nodes and
+// edges. Edge dependencies are indexed like a.neighbors. It returns the
+// accumulated values and the number of sources used.
+func brandes[K comparable](a *indexedAdjacency[K], options CentralityOptions[K]) ([]float64, [][]float64, int) {
+	n := len(a.nodes)
+	sources := allIndices(n)
+	if options.Pivots > 0 && options.Pivots < n {
+		random := rand.New(rand.NewSource(options.Seed))
+		random.Shuffle(n, func(i, j int) { sources[i], sources[j] = sources[j], sources[i] })
+		sources = sources[:options.Pivots]
+	}
+
+	workers := options.workers()
+	nodeScores := make([][]float64, workers)
+	edgeScores := make([][][]float64, workers)
+	forEachSource(sources, workers, func(worker, source int) {
+		if nodeScores[worker] == nil {
+			nodeScores[worker] = make([]float64, n)
+			edgeScores[worker] = make([][]float64, n)
+			for v := range a.neighbors {
+				edgeScores[worker][v] = make([]float64, len(a.neighbors[v]))
+			}
+		}
+		dag := a.search(source, options.Weight != nil)
+		dependency := make([]float64, n)
+		for i := len(dag.order) - 1; i >= 0; i-- {
+			w := dag.order[i]
+			for _, edge := range dag.predecessors[w] {
+				share := dag.paths[edge.v] / dag.paths[w] * (1 + dependency[w])
+				dependency[edge.v] += share
+				edgeScores[worker][edge.v][edge.k] += share
+			}
+			if w != source {
+				nodeScores[worker][w] += dependency[w]
+			}
+		}
+	})
+
+	nodes := make([]float64, n)
+	edges := make([][]float64, n)
+	for v := range edges {
+		edges[v] = make([]float64, len(a.neighbors[v]))
+	}
+	for worker := range nodeScores {
+		if nodeScores[worker] == nil {
+			continue
+		}
+		for v := range nodes {
+			nodes[v] += nodeScores[worker][v]
+			for k := range edges[v] {
+				edges[v][k] += edgeScores[worker][v][k]
+			}
+		}
+	}
+	return nodes, edges, len(sources)
+}
+
+/*
+BetweennessCentrality returns the betweenness of every node, the sum over all pairs of other nodes of
+the fraction of shortest paths between them that pass through the node.
+
+Parameters:
+- g: The graph.
+- options: The edge weights, the number of workers and the pivots. Normalized divides by the number of
+pairs of other nodes, (n-1)(n-2)/2 in undirected and (n-1)(n-2) in directed graphs. With k pivots,
+the result is estimated from shortest paths starting at k random nodes and scaled by n/k.
+
+Returns:
+- map[K]float64: The betweenness of every node.
+- error: An error if an edge has a negative weight.
+
+References:
+  - Brandes, U. (2001). A faster algorithm for betweenness centrality. Journal of Mathematical Sociology, 25(2), 163-177.
+  - Brandes, U., & Pich, C. (2007). Centrality estimation in large networks. International Journal of Bifurcation and Chaos, 17(07), 2303-2318.
+*/
+func BetweennessCentrality[K comparable](g GraphView[K], options CentralityOptions[K]) (map[K]float64, error) {
+	a := newIndexedAdjacency(g, options.Weight)
+	if err := a.checkNonNegative(); err != nil {
+		return nil, err
+	}
+	scores, _, sources := brandes(a, options)
+	n := len(a.nodes)
+
+	// every pair of an undirected graph is counted in both directions
+	scale := 1.0
+	if options.Normalized {
+		if n > 2 {
+			scale = 1 / float64((n-1)*(n-2))
+		}
+	} else if !a.directed {
+		scale = 0.5
+	}
+	if sources > 0 {
+		scale *= float64(n) / float64(sources)
+	}
+
+	centrality := make(map[K]float64, n)
+	for i, node := range a.nodes {
+		centrality[node] = scores[i] * scale
+	}
+	return centrality, nil
+}
+
+// EdgeBetweennessCentrality returns the betweenness of every edge, the sum
+// over all pairs of nodes of the fraction of shortest paths between them that
+// use the edge. Edges are keyed by their endpoints; in undirected graphs, both
+// orders are present with the same value. Normalized divides by the number of
+// pairs of nodes, n(n-1)/2 in undirected and n(n-1) in directed graphs. The
+// other options are those of BetweennessCentrality, and it returns an error if
+// an edge has a negative weight.
+func EdgeBetweennessCentrality[K comparable](g GraphView[K], options CentralityOptions[K]) (map[KeyedEdge[K]]float64, error) {
+	a := newIndexedAdjacency(g, options.Weight)
+	if err := a.checkNonNegative(); err != nil {
+		return nil, err
+	}
+	_, scores, sources := brandes(a, options)
+	n := len(a.nodes)
+
+	scale := 1.0
+	if options.Normalized {
+		if n > 1 {
+			scale = 1 / float64(n*(n-1))
+		}
+	} else if !a.directed {
+		scale = 0.5
+	}
+	if sources > 0 {
+		scale *= float64(n) / float64(sources)
+	}
+
+	centrality := make(map[KeyedEdge[K]]float64)
+	for v, neighbors := range a.neighbors {
+		for k, w := range neighbors {
+			edge := KeyedEdge[K]{Node1: a.nodes[v], Node2: a.nodes[w]}
+			centrality[edge] += scores[v][k] * scale
+			if !a.directed && v != w {
+				// the dependency of the opposite direction is added from the other row
+				reverse := KeyedEdge[K]{Node1: a.nodes[w], Node2: a.nodes[v]}
+				centrality[reverse] += scores[v][k] * scale
+			}
+		}
+	}
+	return centrality, nil
+}
@@ -0,0 +1,132 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestDegreeAndDistanceCentrality(t *testing.T) {
+	star := StarGraph(5)
+	degree := DegreeCentrality[Node](star)
+	if degree[0] != 1 || degree[3] != 0.25 {
+		t.Errorf("Expected 1 and 0.25, but got %v and %v", degree[0], degree[3])
+	}
+
+	closeness, _ := ClosenessCentrality[Node](star, CentralityOptions[Node]{})
+	if closeness[0] != 1 || !almostEqual(closeness[1], 4.0/7) {
+		t.Errorf("Expected 1 and %v, but got %v and %v", 4.0/7, closeness[0], closeness[1])
+	}
+
+	harmonic, _ := HarmonicCentrality[Node](PathGraph(4), CentralityOptions[Node]{})
+	if !almostEqual(harmonic[0], 11.0/6) || !almostEqual(harmonic[1], 2.5) {
+		t.Errorf("Expected %v and 2.5, but got %v and %v", 11.0/6, harmonic[0], harmonic[1])
+	}
+
+	// Test case 2: Disconnected graphs
+	// 0-1 and 2-3-4 are separate components
+	g := PathGraph(2)
+	g.AddEdge(Edge{Node1: 2, Node2: 3})
+	g.AddEdge(Edge{Node1: 3, Node2: 4})
+	g.AddNode(5)
+	closeness, _ = ClosenessCentrality[Node](g, CentralityOptions[Node]{Normalized: true})
+	if !almostEqual(closeness[0], 0.2) || !almostEqual(closeness[3], 0.4) || closeness[5] != 0 {
+		t.Errorf("Expected 0.2, 0.4 and 0, but got %v, %v and %v", closeness[0], closeness[3], closeness[5])
+	}
+	harmonic, _ = HarmonicCentrality[Node](g, CentralityOptions[Node]{Normalized: true})
+	if !almostEqual(harmonic[2], 0.3) || harmonic[5] != 0 {
+		t.Errorf("Expected 0.3 and 0, but got %v and %v", harmonic[2], harmonic[5])
+	}
+
+	// Test case 3: Weighted graphs
+	weighted := weightedDiamond()
+	closeness, _ = ClosenessCentrality[Node](weighted, CentralityOptions[Node]{Weight: weighted.WeightFunc()})
+	if !almostEqual(closeness[0], 3.0/6) {
+		t.Errorf("Expected 0.5, but got %v", closeness[0])
+	}
+
+	// Test case 4: Negative weights
+	negative := func(u, v Node) float64 { return -1 }
+	if _, err := ClosenessCentrality[Node](weighted, CentralityOptions[Node]{Weight: negative}); err == nil {
+		t.Errorf("Expected an error for negative weights")
+	}
+	if _, err := HarmonicCentrality[Node](weighted, CentralityOptions[Node]{Weight: negative}); err == nil {
+		t.Errorf("Expected an error for negative weights")
+	}
+	if _, err := BetweennessCentrality[Node](weighted, CentralityOptions[Node]{Weight: negative}); err == nil {
+		t.Errorf("Expected an error for negative weights")
+	}
+	if _, err := EdgeBetweennessCentrality[Node](weighted, CentralityOptions[Node]{Weight: negative}); err == nil {
+		t.Errorf("Expected an error for negative weights")
+	}
+}
+
+func TestBetweennessCentrality(t *testing.T) {
+	star := StarGraph(5)
+	betweenness, _ := BetweennessCentrality[Node](star, CentralityOptions[Node]{})
+	if betweenness[0] != 6 || betweenness[1] != 0 {
+		t.Errorf("Expected 6 and 0, but got %v and %v", betweenness[0], betweenness[1])
+	}
+	betweenness, _ = BetweennessCentrality[Node](star, CentralityOptions[Node]{Normalized: true})
+	if !almostEqual(betweenness[0], 1) {
+		t.Errorf("Expected 1, but got %v", betweenness[0])
+	}
+
+	// Test case 2: Several shortest paths
+	// the two paths between opposite nodes of a square share the pair equally
+	betweenness, _ = BetweennessCentrality[Node](CycleGraph(4), CentralityOptions[Node]{Workers: 3})
+	for node := Node(0); node < 4; node++ {
+		if !almostEqual(betweenness[node], 0.5) {
+			t.Errorf("Expected 0.5 for node %d, but got %v", node, betweenness[node])
+		}
+	}
+
+	// Test case 3: Weighted and directed graphs
+	weighted := weightedDiamond()
+	betweenness, _ = BetweennessCentrality[Node](weighted, CentralityOptions[Node]{Weight: weighted.WeightFunc()})
+	expected := map[Node]float64{0: 1, 1: 1, 2: 0, 3: 0, 4: 0}
+	for node, value := range expected {
+		if !almostEqual(betweenness[node], value) {
+			t.Errorf("Expected %v for node %d, but got %v", value, node, betweenness[node])
+		}
+	}
+	d := &DirectedGraph{}
+	d.AddEdge(Edge{Node1: 0, Node2: 1})
+	d.AddEdge(Edge{Node1: 1, Node2: 2})
+	betweenness, _ = BetweennessCentrality[Node](d, CentralityOptions[Node]{})
+	if betweenness[1] != 1 || betweenness[0] != 0 {
+		t.Errorf("Expected 1 and 0, but got %v and %v", betweenness[1], betweenness[0])
+	}
+
+	// Test case 4: Pivots
+	// every source of a cycle contributes the same total, so the estimates add up to the exact total
+	cycle := CycleGraph(7)
+	exact, _ := BetweennessCentrality[Node](cycle, CentralityOptions[Node]{})
+	approximate, _ := BetweennessCentrality[Node](cycle, CentralityOptions[Node]{Pivots: 3, Seed: 42})
+	exactTotal, approximateTotal := 0.0, 0.0
+	for node := range cycle.Nodes {
+		exactTotal += exact[node]
+		approximateTotal += approximate[node]
+	}
+	if !almostEqual(exactTotal, approximateTotal) {
+		t.Errorf("Expected %v, but got %v", exactTotal, approximateTotal)
+	}
+}
+
+func TestEdgeBetweennessCentrality(t *testing.T) {
+	betweenness, _ := EdgeBetweennessCentrality[Node](PathGraph(4), CentralityOptions[Node]{})
+	if len(betweenness) != 6 {
+		t.Errorf("Expected 6 entries, but got %d", len(betweenness))
+	}
+	if betweenness[KeyedEdge[Node]{Node1: 0, Node2: 1}] != 3 || betweenness[KeyedEdge[Node]{Node1: 1, Node2: 0}] != 3 {
+		t.Errorf("Expected 3, but got %v", betweenness[KeyedEdge[Node]{Node1: 0, Node2: 1}])
+	}
+	if betweenness[KeyedEdge[Node]{Node1: 2, Node2: 1}] != 4 {
+		t.Errorf("Expected 4, but got %v", betweenness[KeyedEdge[Node]{Node1: 2, Node2: 1}])
+	}
+
+	// Test case 2: Normalized
+	betweenness, _ = EdgeBetweennessCentrality[Node](StarGraph(5), CentralityOptions[Node]{Normalized: true})
+	// every edge of the star lies on the paths from its leaf to the 4 other nodes
+	if !almostEqual(betweenness[KeyedEdge[Node]{Node1: 0, Node2: 2}], 8.0/20) {
+		t.Errorf("Expected 0.4, but got %v", betweenness[KeyedEdge[Node]{Node1: 0, Node2: 2}])
+	}
+}
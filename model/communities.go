@@ -1,5 +1,463 @@
 package model
 
-// some common partitioning algorithms:
-// - https://patterns.eecs.berkeley.edu/?page_id=571#1_Find_a_representation_model
-// - https://networkx.org/documentation/stable/reference/algorithms/community.html
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// leidenRandomness is the θ parameter of the Leiden refinement phase. Smaller
+// values make the choice of the refined community closer to greedy.
+const leidenRandomness = 0.01
+
+type weightedNeighbor struct {
+	node   int
+	weight float64
+}
+
+// communityGraph is the index based, weighted adjacency used by the modularity
+// optimisers. Self-loops are stored with twice their weight, so the strength of
+// a node is the plain sum of its row and total is equal to 2m.
+type communityGraph struct {
+	adj      [][]weightedNeighbor
+	strength []float64
+	total    float64
+}
+
+// newCommunityGraph converts an UndirectedGraph into a communityGraph. When
+// weight is nil every entry of the adjacency lists counts as a unit of weight,
+// so parallel edges add up. Otherwise weight gives the total weight between two
+// connected nodes. The returned slice maps indices back to the original nodes.
+func newCommunityGraph(g *UndirectedGraph, weight func(u, v Node) float64) (*communityGraph, []Node) {
+	nodes := GetDictKeys(g.Nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+	index := make(map[Node]int, len(nodes))
+	for i, node := range nodes {
+		index[node] = i
+	}
+
+	rows := make([]map[int]float64, len(nodes))
+	for i, node := range nodes {
+		rows[i] = make(map[int]float64, len(g.Edges[node]))
+		for _, neighbor := range g.Edges[node] {
+			j, ok := index[neighbor]
+			switch {
+			case !ok:
+			case weight == nil:
+				rows[i][j]++
+			case i == j:
+				rows[i][j] = 2 * weight(node, neighbor)
+			default:
+				rows[i][j] = weight(node, neighbor)
+			}
+		}
+	}
+	return newCommunityGraphFromRows(rows), nodes
+}
+
+func newCommunityGraphFromRows(rows []map[int]float64) *communityGraph {
+	cg := &communityGraph{
+		adj:      make([][]weightedNeighbor, len(rows)),
+		strength: make([]float64, len(rows)),
+	}
+	for i, row := range rows {
+		neighbors := make([]weightedNeighbor, 0, len(row))
+		for j, w := range row {
+			neighbors = append(neighbors, weightedNeighbor{node: j, weight: w})
+			cg.strength[i] += w
+		}
+		sort.Slice(neighbors, func(a, b int) bool { return neighbors[a].node < neighbors[b].node })
+		cg.adj[i] = neighbors
+		cg.total += cg.strength[i]
+	}
+	return cg
+}
+
+// aggregate collapses every community into a single node.
+func (cg *communityGraph) aggregate(community []int, k int) *communityGraph {
+	rows := make([]map[int]float64, k)
+	for c := range rows {
+		rows[c] = make(map[int]float64)
+	}
+	for i, neighbors := range cg.adj {
+		ci := community[i]
+		for _, e := range neighbors {
+			rows[ci][community[e.node]] += e.weight
+		}
+	}
+	return newCommunityGraphFromRows(rows)
+}
+
+// modularity returns the Newman modularity of the given assignment.
+func (cg *communityGraph) modularity(community []int, resolution float64) float64 {
+	if cg.total == 0 {
+		return 0
+	}
+	internal := make(map[int]float64)
+	totals := make(map[int]float64)
+	for i, neighbors := range cg.adj {
+		ci := community[i]
+		totals[ci] += cg.strength[i]
+		for _, e := range neighbors {
+			if community[e.node] == ci {
+				internal[ci] += e.weight
+			}
+		}
+	}
+
+	q := 0.0
+	for c, tot := range totals {
+		share := tot / cg.total
+		q += internal[c]/cg.total - resolution*share*share
+	}
+	return q
+}
+
+// neighborCommunities accumulates the weight from node i towards each community
+// of its neighbours. Communities are reported in the order they are first seen,
+// which keeps the optimisers deterministic for a given seed.
+type neighborCommunities struct {
+	weights []float64
+	seen    []bool
+	list    []int
+}
+
+func newNeighborCommunities(n int) *neighborCommunities {
+	return &neighborCommunities{
+		weights: make([]float64, n),
+		seen:    make([]bool, n),
+	}
+}
+
+func (nc *neighborCommunities) collect(cg *communityGraph, i int, community []int, include func(j int) bool) {
+	for _, c := range nc.list {
+		nc.weights[c] = 0
+		nc.seen[c] = false
+	}
+	nc.list = nc.list[:0]
+	for _, e := range cg.adj[i] {
+		if e.node == i || (include != nil && !include(e.node)) {
+			continue
+		}
+		c := community[e.node]
+		if !nc.seen[c] {
+			nc.seen[c] = true
+			nc.list = append(nc.list, c)
+		}
+		nc.weights[c] += e.weight
+	}
+}
+
+// bestCommunity returns the community that maximises the modularity gain of
+// inserting node i, assuming i has already been removed from its community.
+func (cg *communityGraph) bestCommunity(i int, current int, nc *neighborCommunities, totals []float64, resolution float64) int {
+	ki := cg.strength[i]
+	best := current
+	bestGain := nc.weights[current] - resolution*totals[current]*ki/cg.total
+	for _, c := range nc.list {
+		gain := nc.weights[c] - resolution*totals[c]*ki/cg.total
+		if gain > bestGain+1e-12 {
+			best, bestGain = c, gain
+		}
+	}
+	return best
+}
+
+// moveNodes is the local moving phase of the Louvain method. It sweeps over all
+// nodes in random order until no single move improves modularity and reports
+// whether any node changed its community.
+func (cg *communityGraph) moveNodes(community []int, resolution float64, rng *rand.Rand) bool {
+	n := len(cg.adj)
+	totals := make([]float64, n)
+	for i, c := range community {
+		totals[c] += cg.strength[i]
+	}
+	nc := newNeighborCommunities(n)
+	order := rng.Perm(n)
+
+	improved := false
+	for {
+		moves := 0
+		for _, i := range order {
+			current := community[i]
+			nc.collect(cg, i, community, nil)
+			totals[current] -= cg.strength[i]
+			best := cg.bestCommunity(i, current, nc, totals, resolution)
+			totals[best] += cg.strength[i]
+			if best != current {
+				community[i] = best
+				moves++
+			}
+		}
+		if moves == 0 {
+			return improved
+		}
+		improved = true
+	}
+}
+
+// moveNodesFast is the queue based local moving phase of the Leiden algorithm.
+// Only the neighbours of nodes that moved are visited again.
+func (cg *communityGraph) moveNodesFast(community []int, resolution float64, rng *rand.Rand) {
+	n := len(cg.adj)
+	totals := make([]float64, n)
+	for i, c := range community {
+		totals[c] += cg.strength[i]
+	}
+	nc := newNeighborCommunities(n)
+
+	queue := rng.Perm(n)
+	queued := make([]bool, n)
+	for i := range queued {
+		queued[i] = true
+	}
+	for head := 0; head < len(queue); head++ {
+		i := queue[head]
+		queued[i] = false
+		current := community[i]
+		nc.collect(cg, i, community, nil)
+		totals[current] -= cg.strength[i]
+		best := cg.bestCommunity(i, current, nc, totals, resolution)
+		totals[best] += cg.strength[i]
+		if best == current {
+			continue
+		}
+		community[i] = best
+		for _, e := range cg.adj[i] {
+			if !queued[e.node] && community[e.node] != best {
+				queued[e.node] = true
+				queue = append(queue, e.node)
+			}
+		}
+	}
+}
+
+// refine splits every community found by the local moving phase into
+// well-connected sub-communities, as described by Traag et al.
+func (cg *communityGraph) refine(community []int, k int, resolution float64, rng *rand.Rand) ([]int, int) {
+	n := len(cg.adj)
+	refined := make([]int, n)
+	size := make([]int, n)
+	totals := make([]float64, n)
+	external := make([]float64, n)
+	communityTotals := make([]float64, k)
+	for i := range refined {
+		refined[i] = i
+		size[i] = 1
+		totals[i] = cg.strength[i]
+		communityTotals[community[i]] += cg.strength[i]
+		for _, e := range cg.adj[i] {
+			if e.node != i && community[e.node] == community[i] {
+				external[i] += e.weight
+			}
+		}
+	}
+
+	nc := newNeighborCommunities(n)
+	candidates := make([]int, 0)
+	weights := make([]float64, 0)
+	for _, i := range rng.Perm(n) {
+		if size[refined[i]] != 1 {
+			continue
+		}
+		c := community[i]
+		ki := cg.strength[i]
+		if external[i] < resolution*ki*(communityTotals[c]-ki)/cg.total {
+			continue
+		}
+
+		nc.collect(cg, i, refined, func(j int) bool { return community[j] == c })
+		candidates = append(candidates[:0], refined[i])
+		weights = append(weights[:0], 0)
+		maxGain := 0.0
+		for _, r := range nc.list {
+			if external[r] < resolution*totals[r]*(communityTotals[c]-totals[r])/cg.total {
+				continue
+			}
+			gain := nc.weights[r] - resolution*ki*totals[r]/cg.total
+			if gain < 0 {
+				continue
+			}
+			candidates = append(candidates, r)
+			weights = append(weights, gain)
+			maxGain = math.Max(maxGain, gain)
+		}
+
+		sum := 0.0
+		for idx, gain := range weights {
+			weights[idx] = math.Exp((gain - maxGain) / leidenRandomness)
+			sum += weights[idx]
+		}
+		pick := rng.Float64() * sum
+		chosen := candidates[len(candidates)-1]
+		for idx, w := range weights {
+			if pick < w {
+				chosen = candidates[idx]
+				break
+			}
+			pick -= w
+		}
+
+		if chosen == refined[i] {
+			continue
+		}
+		size[refined[i]]--
+		totals[refined[i]] -= ki
+		external[chosen] += external[i] - 2*nc.weights[chosen]
+		refined[i] = chosen
+		size[chosen]++
+		totals[chosen] += ki
+	}
+	return refined, renumberCommunities(refined)
+}
+
+// renumberCommunities relabels community ids in place to 0..k-1, in order of
+// first appearance, and returns k.
+func renumberCommunities(community []int) int {
+	labels := make(map[int]int)
+	for i, c := range community {
+		label, ok := labels[c]
+		if !ok {
+			label = len(labels)
+			labels[c] = label
+		}
+		community[i] = label
+	}
+	return len(labels)
+}
+
+func identityCommunities(n int) []int {
+	community := make([]int, n)
+	for i := range community {
+		community[i] = i
+	}
+	return community
+}
+
+func partitionFromMembership(nodes []Node, membership []int) Partition {
+	partition := make(Partition, len(nodes))
+	for i, node := range nodes {
+		partition[node] = membership[i]
+	}
+	return partition
+}
+
+// Louvain detects communities with the Louvain modularity optimisation method.
+//
+// Parameters:
+//   - g: The graph to partition. Parallel edges add up to heavier links. Use
+//     WeightedLouvain for explicit edge weights.
+//   - resolution: The resolution parameter γ. Values above 1 favour smaller
+//     communities, values below 1 favour larger ones. Use 1 for the standard
+//     Newman modularity.
+//   - seed: Seed of the random generator that decides the order in which nodes
+//     are visited. The same seed always produces the same partition.
+//
+// Returns:
+//
+//	A Partition of every node into communities (numbered from 0) and the modularity
+//	of that partition.
+//
+// References: [1] Blondel, V.D., Guillaume, J.-L., Lambiotte, R. and Lefebvre, E.,
+// "Fast unfolding of communities in large networks", J. Stat. Mech., P10008, 2008.
+func Louvain(g *UndirectedGraph, resolution float64, seed int64) (Partition, float64) {
+	cg, nodes := newCommunityGraph(g, nil)
+	return louvain(cg, nodes, resolution, seed)
+}
+
+// WeightedLouvain runs the Louvain method on a weighted graph, maximising the
+// weighted modularity. The parameters and results are the same as for Louvain.
+func WeightedLouvain(g *WeightedUndirectedGraph, resolution float64, seed int64) (Partition, float64) {
+	cg, nodes := newCommunityGraph(&g.UndirectedGraph, g.Weight)
+	return louvain(cg, nodes, resolution, seed)
+}
+
+func louvain(cg *communityGraph, nodes []Node, resolution float64, seed int64) (Partition, float64) {
+	rng := rand.New(rand.NewSource(seed))
+	original := cg
+	membership := identityCommunities(len(nodes))
+	if cg.total == 0 {
+		return partitionFromMembership(nodes, membership), 0
+	}
+
+	for {
+		community := identityCommunities(len(cg.adj))
+		if !cg.moveNodes(community, resolution, rng) {
+			break
+		}
+		k := renumberCommunities(community)
+		for i := range membership {
+			membership[i] = community[membership[i]]
+		}
+		cg = cg.aggregate(community, k)
+	}
+	renumberCommunities(membership)
+	return partitionFromMembership(nodes, membership), original.modularity(membership, resolution)
+}
+
+// Leiden detects communities with the Leiden algorithm. It improves on Louvain
+// by refining every community before aggregation, which guarantees that the
+// communities it returns are connected.
+//
+// Parameters:
+//   - g: The graph to partition. Parallel edges add up to heavier links. Use
+//     WeightedLeiden for explicit edge weights.
+//   - resolution: The resolution parameter γ, see Louvain.
+//   - seed: Seed of the random generator used for the node order and for the
+//     randomised refinement. The same seed always produces the same partition.
+//
+// Returns:
+//
+//	A Partition of every node into communities (numbered from 0) and the modularity
+//	of that partition.
+//
+// References: [1] Traag, V.A., Waltman, L. and van Eck, N.J., "From Louvain to
+// Leiden: guaranteeing well-connected communities", Sci. Rep. 9, 5233, 2019.
+func Leiden(g *UndirectedGraph, resolution float64, seed int64) (Partition, float64) {
+	cg, nodes := newCommunityGraph(g, nil)
+	return leiden(cg, nodes, resolution, seed)
+}
+
+// WeightedLeiden runs the Leiden algorithm on a weighted graph, maximising the
+// weighted modularity. The parameters and results are the same as for Leiden.
+func WeightedLeiden(g *WeightedUndirectedGraph, resolution float64, seed int64) (Partition, float64) {
+	cg, nodes := newCommunityGraph(&g.UndirectedGraph, g.Weight)
+	return leiden(cg, nodes, resolution, seed)
+}
+
+func leiden(cg *communityGraph, nodes []Node, resolution float64, seed int64) (Partition, float64) {
+	rng := rand.New(rand.NewSource(seed))
+	original := cg
+	membership := identityCommunities(len(nodes))
+	if cg.total == 0 {
+		return partitionFromMembership(nodes, membership), 0
+	}
+
+	community := identityCommunities(len(cg.adj))
+	for {
+		cg.moveNodesFast(community, resolution, rng)
+		k := renumberCommunities(community)
+		if k == len(cg.adj) {
+			break
+		}
+		refined, r := cg.refine(community, k, resolution, rng)
+		if r == len(cg.adj) {
+			break
+		}
+
+		next := make([]int, r)
+		for i, rc := range refined {
+			next[rc] = community[i]
+		}
+		for i := range membership {
+			membership[i] = refined[membership[i]]
+		}
+		cg = cg.aggregate(refined, r)
+		community = next
+	}
+	for i := range membership {
+		membership[i] = community[membership[i]]
+	}
+	renumberCommunities(membership)
+	return partitionFromMembership(nodes, membership), original.modularity(membership, resolution)
+}
@@ -0,0 +1,289 @@
+package model
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// indexAdjacency converts the adjacency lists of g into integer indices so that
+// the propagation algorithms can work on slices instead of maps. Nodes are
+// sorted, which keeps the results reproducible for a given seed.
+func indexAdjacency(g *UndirectedGraph) ([]Node, [][]int) {
+	nodes := GetDictKeys(g.Nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+	index := make(map[Node]int, len(nodes))
+	for i, node := range nodes {
+		index[node] = i
+	}
+
+	adj := make([][]int, len(nodes))
+	for i, node := range nodes {
+		adj[i] = make([]int, 0, len(g.Edges[node]))
+		for _, neighbor := range g.Edges[node] {
+			if j, ok := index[neighbor]; ok && j != i {
+				adj[i] = append(adj[i], j)
+			}
+		}
+	}
+	return nodes, adj
+}
+
+// labelCounter counts how often each label occurs among the neighbours of a
+// node without allocating a map for every visit.
+type labelCounter struct {
+	counts  []float64
+	touched []int
+}
+
+func newLabelCounter(n int) *labelCounter {
+	return &labelCounter{counts: make([]float64, n)}
+}
+
+func (lc *labelCounter) reset() {
+	for _, label := range lc.touched {
+		lc.counts[label] = 0
+	}
+	lc.touched = lc.touched[:0]
+}
+
+func (lc *labelCounter) add(label int, weight float64) {
+	if lc.counts[label] == 0 {
+		lc.touched = append(lc.touched, label)
+	}
+	lc.counts[label] += weight
+}
+
+// maxLabels returns all labels whose count is maximal.
+func (lc *labelCounter) maxLabels(buffer []int) []int {
+	buffer = buffer[:0]
+	best := 0.0
+	for _, label := range lc.touched {
+		count := lc.counts[label]
+		if count > best+1e-9 {
+			best = count
+			buffer = append(buffer[:0], label)
+		} else if count >= best-1e-9 {
+			buffer = append(buffer, label)
+		}
+	}
+	return buffer
+}
+
+func containsLabel(labels []int, label int) bool {
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// LabelPropagation detects communities with asynchronous label propagation.
+// Every node starts with its own label and repeatedly adopts the label that is
+// most frequent among its neighbours, visiting nodes in random order. Ties are
+// broken at random. The algorithm runs in near linear time per sweep and stops
+// once every node carries one of the most frequent labels of its neighbourhood.
+//
+// Parameters:
+//   - g: The graph to partition.
+//   - seed: Seed of the random generator used for the visiting order and ties.
+//
+// Returns:
+//
+//	A Partition of every node into communities, numbered from 0.
+//
+// References: [1] Raghavan, U.N., Albert, R. and Kumara, S., "Near linear time
+// algorithm to detect community structures in large-scale networks",
+// Phys. Rev. E, 76, 036106, 2007.
+func LabelPropagation(g *UndirectedGraph, seed int64) Partition {
+	rng := rand.New(rand.NewSource(seed))
+	nodes, adj := indexAdjacency(g)
+	labels := identityCommunities(len(nodes))
+	counter := newLabelCounter(len(nodes))
+	var candidates []int
+
+	for changed := true; changed; {
+		changed = false
+		for _, i := range rng.Perm(len(nodes)) {
+			if len(adj[i]) == 0 {
+				continue
+			}
+			counter.reset()
+			for _, j := range adj[i] {
+				counter.add(labels[j], 1)
+			}
+			candidates = counter.maxLabels(candidates)
+			if containsLabel(candidates, labels[i]) {
+				continue
+			}
+			labels[i] = candidates[rng.Intn(len(candidates))]
+			changed = true
+		}
+	}
+	renumberCommunities(labels)
+	return partitionFromMembership(nodes, labels)
+}
+
+// SynchronousLabelPropagation detects communities with synchronous label
+// propagation: in every iteration all nodes adopt the most frequent label of
+// their neighbours from the previous iteration at the same time. Ties keep the
+// current label when possible and otherwise choose the smallest one, so the
+// result is deterministic. Synchronous updates may oscillate on bipartite
+// structures, hence the iteration cap.
+//
+// Parameters:
+//   - g: The graph to partition.
+//   - maxIterations: Upper bound on the number of synchronous sweeps.
+//
+// Returns:
+//
+//	A Partition of every node into communities, numbered from 0.
+func SynchronousLabelPropagation(g *UndirectedGraph, maxIterations int) Partition {
+	nodes, adj := indexAdjacency(g)
+	labels := identityCommunities(len(nodes))
+	next := make([]int, len(nodes))
+	counter := newLabelCounter(len(nodes))
+	var candidates []int
+
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		changed := false
+		for i := range adj {
+			next[i] = labels[i]
+			if len(adj[i]) == 0 {
+				continue
+			}
+			counter.reset()
+			for _, j := range adj[i] {
+				counter.add(labels[j], 1)
+			}
+			candidates = counter.maxLabels(candidates)
+			if containsLabel(candidates, labels[i]) {
+				continue
+			}
+			smallest := candidates[0]
+			for _, label := range candidates[1:] {
+				if label < smallest {
+					smallest = label
+				}
+			}
+			next[i] = smallest
+			changed = true
+		}
+		labels, next = next, labels
+		if !changed {
+			break
+		}
+	}
+	renumberCommunities(labels)
+	return partitionFromMembership(nodes, labels)
+}
+
+// isConnectedAdjacency reports whether an index adjacency forms a single
+// connected component.
+func isConnectedAdjacency(adj [][]int) bool {
+	if len(adj) == 0 {
+		return true
+	}
+	visited := make([]bool, len(adj))
+	visited[0] = true
+	stack := []int{0}
+	count := 1
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, j := range adj[i] {
+			if !visited[j] {
+				visited[j] = true
+				count++
+				stack = append(stack, j)
+			}
+		}
+	}
+	return count == len(adj)
+}
+
+// AsyncFluidCommunities detects exactly k communities with the asynchronous
+// fluid communities algorithm. Each community starts at a random node and
+// behaves like a fluid with total density 1 that expands and contracts while
+// competing with the other communities for nodes.
+//
+// Parameters:
+//   - g: The graph to partition. It has to be connected.
+//   - k: The number of communities to find, between 1 and the number of nodes.
+//   - maxIterations: Upper bound on the number of sweeps over all nodes. Use 0
+//     to iterate until convergence.
+//   - seed: Seed of the random generator used for the initial communities,
+//     the visiting order and ties.
+//
+// Returns:
+//
+//	A Partition of every node into communities, numbered from 0, or an error if k
+//	is out of range or the graph is not connected.
+//
+// References: [1] Parés, F. et al., "Fluid Communities: A Competitive, Scalable
+// and Diverse Community Detection Algorithm", Complex Networks & Their
+// Applications VI, 2017.
+func AsyncFluidCommunities(g *UndirectedGraph, k int, maxIterations int, seed int64) (Partition, error) {
+	nodes, adj := indexAdjacency(g)
+	if k < 1 || k > len(nodes) {
+		return nil, fmt.Errorf("number of communities must be between 1 and %d, got %d", len(nodes), k)
+	}
+	if !isConnectedAdjacency(adj) {
+		return nil, fmt.Errorf("fluid communities require a connected graph")
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	labels := make([]int, len(nodes))
+	for i := range labels {
+		labels[i] = -1
+	}
+	density := make([]float64, k)
+	sizes := make([]int, k)
+	for c, i := range rng.Perm(len(nodes))[:k] {
+		labels[i] = c
+		sizes[c] = 1
+		density[c] = 1
+	}
+
+	counter := newLabelCounter(k)
+	var candidates []int
+	for iteration := 0; maxIterations <= 0 || iteration < maxIterations; iteration++ {
+		changed := false
+		for _, i := range rng.Perm(len(nodes)) {
+			counter.reset()
+			if labels[i] >= 0 {
+				counter.add(labels[i], density[labels[i]])
+			}
+			for _, j := range adj[i] {
+				if labels[j] >= 0 {
+					counter.add(labels[j], density[labels[j]])
+				}
+			}
+			if len(counter.touched) == 0 {
+				continue
+			}
+			candidates = counter.maxLabels(candidates)
+			if labels[i] >= 0 && containsLabel(candidates, labels[i]) {
+				continue
+			}
+
+			chosen := candidates[rng.Intn(len(candidates))]
+			if previous := labels[i]; previous >= 0 {
+				sizes[previous]--
+				if sizes[previous] > 0 {
+					density[previous] = 1 / float64(sizes[previous])
+				}
+			}
+			labels[i] = chosen
+			sizes[chosen]++
+			density[chosen] = 1 / float64(sizes[chosen])
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+	renumberCommunities(labels)
+	return partitionFromMembership(nodes, labels), nil
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"testing"
+)
+
+func countCommunities(partition map[Node]int) int {
+	communities := map[int]bool{}
+	for _, community := range partition {
+		communities[community] = true
+	}
+	return len(communities)
+}
+
+func TestLabelPropagation(t *testing.T) {
+	// Test case 1: Two disconnected cliques end up in two communities
+	g := CompleteGraph(5)
+	for i := 5; i < 10; i++ {
+		for j := i + 1; j < 10; j++ {
+			g.AddEdge(Edge{Node1: Node(i), Node2: Node(j)})
+		}
+	}
+	partition := LabelPropagation(g, 3)
+	if countCommunities(partition) != 2 {
+		t.Errorf("Expected 2 communities, but got %v", partition)
+	}
+	if partition[0] == partition[5] {
+		t.Errorf("Expected the cliques in different communities, but got %v", partition)
+	}
+
+	// Test case 2: Isolated nodes keep their own label
+	g.AddNode(42)
+	partition = LabelPropagation(g, 3)
+	if countCommunities(partition) != 3 {
+		t.Errorf("Expected 3 communities, but got %v", partition)
+	}
+}
+
+func TestSynchronousLabelPropagation(t *testing.T) {
+	g := PathGraph(6)
+	partition := SynchronousLabelPropagation(g, 20)
+	if len(partition) != 6 {
+		t.Errorf("Expected all 6 nodes to be assigned, but got %v", partition)
+	}
+
+	// Test case 2: Zero iterations leaves every node in its own community
+	partition = SynchronousLabelPropagation(g, 0)
+	if countCommunities(partition) != 6 {
+		t.Errorf("Expected 6 communities, but got %v", partition)
+	}
+}
+
+func TestAsyncFluidCommunities(t *testing.T) {
+	partition, err := AsyncFluidCommunities(twoCliques(), 2, 0, 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if countCommunities(partition) != 2 || partition[0] == partition[9] {
+		t.Errorf("Expected the cliques in 2 communities, but got %v", partition)
+	}
+
+	// Test case 2: Invalid number of communities
+	if _, err := AsyncFluidCommunities(twoCliques(), 11, 0, 5); err == nil {
+		t.Errorf("Expected an error for k larger than the number of nodes")
+	}
+
+	// Test case 3: Disconnected graph
+	g := PathGraph(3)
+	g.AddNode(7)
+	if _, err := AsyncFluidCommunities(g, 2, 0, 5); err == nil {
+		t.Errorf("Expected an error for a disconnected graph")
+	}
+}
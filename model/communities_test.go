@@ -0,0 +1,73 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+// twoCliques returns two complete graphs on 5 nodes joined by a single edge.
+func twoCliques() *UndirectedGraph {
+	g := &UndirectedGraph{}
+	for offset := 0; offset <= 5; offset += 5 {
+		for i := 0; i < 5; i++ {
+			for j := i + 1; j < 5; j++ {
+				g.AddEdge(Edge{Node1: Node(offset + i), Node2: Node(offset + j)})
+			}
+		}
+	}
+	g.AddEdge(Edge{Node1: 4, Node2: 5})
+	return g
+}
+
+func checkTwoCliques(t *testing.T, partition map[Node]int, modularity float64) {
+	for i := 1; i < 5; i++ {
+		if partition[Node(i)] != partition[0] {
+			t.Errorf("Expected node %d in the community of node 0", i)
+		}
+		if partition[Node(i+5)] != partition[5] {
+			t.Errorf("Expected node %d in the community of node 5", i+5)
+		}
+	}
+	if partition[0] == partition[5] {
+		t.Errorf("Expected the two cliques in different communities")
+	}
+
+	expected := 2 * (10.0/21.0 - 0.25)
+	if math.Abs(modularity-expected) > 1e-9 {
+		t.Errorf("Expected modularity %v, but got %v", expected, modularity)
+	}
+}
+
+func TestLouvain(t *testing.T) {
+	partition, modularity := Louvain(twoCliques(), 1, 42)
+	checkTwoCliques(t, partition, modularity)
+
+	// Test case 2: Same seed gives the same partition
+	again, _ := Louvain(twoCliques(), 1, 42)
+	for node, community := range partition {
+		if again[node] != community {
+			t.Errorf("Expected deterministic result for node %d", node)
+		}
+	}
+
+	// Test case 3: Graph without edges
+	empty := &UndirectedGraph{}
+	empty.AddNodes([]Node{1, 2, 3})
+	partition, modularity = Louvain(empty, 1, 1)
+	if len(partition) != 3 || modularity != 0 {
+		t.Errorf("Expected 3 singleton communities with modularity 0, but got %v and %v", partition, modularity)
+	}
+}
+
+func TestLeiden(t *testing.T) {
+	partition, modularity := Leiden(twoCliques(), 1, 42)
+	checkTwoCliques(t, partition, modularity)
+
+	// Test case 2: A very low resolution merges everything into one community
+	partition, _ = Leiden(twoCliques(), 0.01, 7)
+	for node, community := range partition {
+		if community != partition[0] {
+			t.Errorf("Expected node %d in a single community, but got %d", node, community)
+		}
+	}
+}
@@ -0,0 +1,420 @@
+package model
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// GraphBuilder is implemented by everything that graphs can be read into, the
+// mutable graphs as well as the CSRBuilder.
+type GraphBuilder interface {
+	AddNode(node Node)
+	AddEdge(edge Edge)
+}
+
+// CSRGraph is an immutable undirected graph in compressed sparse row layout.
+// Nodes are numbered with contiguous int32 indices in ascending order of their
+// Node IDs, and the neighbours of the node with index i are
+// neighbors[offsets[i]:offsets[i+1]], sorted by index. Like UndirectedGraph, a
+// self-loop appears twice among the neighbours of its node and parallel edges
+// are kept.
+type CSRGraph struct {
+	offsets   []int64
+	neighbors []int32
+	ids       []Node
+	index     map[Node]int32
+}
+
+// CSRBuilder collects nodes and edges and builds a CSRGraph from them. It
+// implements GraphBuilder, so the io readers can fill it directly without
+// building an UndirectedGraph first.
+type CSRBuilder struct {
+	ids   []Node
+	index map[Node]int32
+	src   []int32
+	dst   []int32
+}
+
+var _ GraphBuilder = (*CSRBuilder)(nil)
+
+// NewCSRBuilder returns an empty CSRBuilder.
+func NewCSRBuilder() *CSRBuilder {
+	return &CSRBuilder{index: make(map[Node]int32)}
+}
+
+func (b *CSRBuilder) nodeIndex(node Node) int32 {
+	if i, ok := b.index[node]; ok {
+		return i
+	}
+	if len(b.ids) == math.MaxInt32 {
+		panic("CSRBuilder: too many nodes for int32 indices")
+	}
+	i := int32(len(b.ids))
+	b.index[node] = i
+	b.ids = append(b.ids, node)
+	return i
+}
+
+// AddNode adds a node without edges.
+func (b *CSRBuilder) AddNode(node Node) {
+	b.nodeIndex(node)
+}
+
+// AddEdge adds an undirected edge between edge.Node1 and edge.Node2.
+func (b *CSRBuilder) AddEdge(edge Edge) {
+	u, v := b.nodeIndex(edge.Node1), b.nodeIndex(edge.Node2)
+	b.src = append(b.src, u)
+	b.dst = append(b.dst, v)
+}
+
+// Build returns the CSRGraph of the nodes and edges added so far. The builder
+// can still be used afterwards.
+func (b *CSRBuilder) Build() *CSRGraph {
+	ids := append([]Node(nil), b.ids...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	index := make(map[Node]int32, len(ids))
+	for i, id := range ids {
+		index[id] = int32(i)
+	}
+	// renumber maps the insertion order indices to the sorted ones
+	renumber := make([]int32, len(b.ids))
+	for i, id := range b.ids {
+		renumber[i] = index[id]
+	}
+
+	offsets := make([]int64, len(ids)+1)
+	for i := range b.src {
+		offsets[renumber[b.src[i]]+1]++
+		offsets[renumber[b.dst[i]]+1]++
+	}
+	for i := 1; i < len(offsets); i++ {
+		offsets[i] += offsets[i-1]
+	}
+	neighbors := make([]int32, offsets[len(ids)])
+	next := append([]int64(nil), offsets[:len(ids)]...)
+	for i := range b.src {
+		u, v := renumber[b.src[i]], renumber[b.dst[i]]
+		neighbors[next[u]] = v
+		next[u]++
+		neighbors[next[v]] = u
+		next[v]++
+	}
+
+	g := &CSRGraph{offsets: offsets, neighbors: neighbors, ids: ids, index: index}
+	g.sortNeighbors()
+	return g
+}
+
+// NewCSRGraph builds the CSRGraph of an UndirectedGraph.
+func NewCSRGraph(g *UndirectedGraph) *CSRGraph {
+	ids := GetDictKeys(g.Nodes)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	index := make(map[Node]int32, len(ids))
+	for i, id := range ids {
+		index[id] = int32(i)
+	}
+
+	offsets := make([]int64, len(ids)+1)
+	for i, id := range ids {
+		offsets[i+1] = offsets[i] + int64(len(g.Edges[id]))
+	}
+	neighbors := make([]int32, 0, offsets[len(ids)])
+	for i, id := range ids {
+		for _, neighbor := range g.Edges[id] {
+			if j, ok := index[neighbor]; ok {
+				neighbors = append(neighbors, j)
+			}
+		}
+		// neighbours that are missing from g.Nodes are dropped
+		offsets[i+1] = int64(len(neighbors))
+	}
+
+	csr := &CSRGraph{offsets: offsets, neighbors: neighbors, ids: ids, index: index}
+	csr.sortNeighbors()
+	return csr
+}
+
+func (g *CSRGraph) sortNeighbors() {
+	for i := 0; i < len(g.ids); i++ {
+		row := g.neighbors[g.offsets[i]:g.offsets[i+1]]
+		sort.Slice(row, func(a, b int) bool { return row[a] < row[b] })
+	}
+}
+
+// NumberOfNodes returns the number of nodes in the graph.
+func (g *CSRGraph) NumberOfNodes() int {
+	return len(g.ids)
+}
+
+// NumberOfEdges returns the number of undirected edges in the graph.
+func (g *CSRGraph) NumberOfEdges() int {
+	return len(g.neighbors) / 2
+}
+
+// NodeAt returns the Node with index i.
+func (g *CSRGraph) NodeAt(i int32) Node {
+	return g.ids[i]
+}
+
+// IndexOf returns the index of the node and whether the graph contains it.
+func (g *CSRGraph) IndexOf(node Node) (int32, bool) {
+	i, ok := g.index[node]
+	return i, ok
+}
+
+// HasNode reports whether the graph contains the node.
+func (g *CSRGraph) HasNode(node Node) bool {
+	_, ok := g.index[node]
+	return ok
+}
+
+// Neighbors returns the indices of the neighbours of the node with index i.
+// The returned slice shares memory with the graph and must not be modified.
+func (g *CSRGraph) Neighbors(i int32) []int32 {
+	return g.neighbors[g.offsets[i]:g.offsets[i+1]]
+}
+
+// Degree returns the degree of the node with index i.
+func (g *CSRGraph) Degree(i int32) int {
+	return int(g.offsets[i+1] - g.offsets[i])
+}
+
+// NodeDegree returns the degree of the node, or 0 if the graph does not contain it.
+func (g *CSRGraph) NodeDegree(node Node) int {
+	i, ok := g.index[node]
+	if !ok {
+		return 0
+	}
+	return g.Degree(i)
+}
+
+// HasEdge reports whether the nodes u and v are connected.
+func (g *CSRGraph) HasEdge(u, v Node) bool {
+	i, ok := g.index[u]
+	j, okj := g.index[v]
+	if !ok || !okj {
+		return false
+	}
+	row := g.Neighbors(i)
+	k := sort.Search(len(row), func(k int) bool { return row[k] >= j })
+	return k < len(row) && row[k] == j
+}
+
+// ToUndirected converts the graph back to an UndirectedGraph.
+func (g *CSRGraph) ToUndirected() *UndirectedGraph {
+	ng := &UndirectedGraph{
+		Nodes: make(map[Node]bool, len(g.ids)),
+		Edges: make(map[Node][]Node, len(g.ids)),
+	}
+	for i, id := range g.ids {
+		ng.Nodes[id] = true
+		row := g.Neighbors(int32(i))
+		if len(row) == 0 {
+			continue
+		}
+		neighbors := make([]Node, len(row))
+		for k, j := range row {
+			neighbors[k] = g.ids[j]
+		}
+		ng.Edges[id] = neighbors
+	}
+	return ng
+}
+
+// BFS returns the nodes reachable from source in breadth-first order, or nil
+// if the graph does not contain source.
+func (g *CSRGraph) BFS(source Node) []Node {
+	s, ok := g.index[source]
+	if !ok {
+		return nil
+	}
+	visited := make([]bool, len(g.ids))
+	visited[s] = true
+	queue := []int32{s}
+	for head := 0; head < len(queue); head++ {
+		for _, v := range g.Neighbors(queue[head]) {
+			if !visited[v] {
+				visited[v] = true
+				queue = append(queue, v)
+			}
+		}
+	}
+	order := make([]Node, len(queue))
+	for k, i := range queue {
+		order[k] = g.ids[i]
+	}
+	return order
+}
+
+// Distances returns the number of hops from source to every node, indexed by
+// node index, with -1 for unreachable nodes.
+func (g *CSRGraph) Distances(source Node) []int32 {
+	dist := make([]int32, len(g.ids))
+	for i := range dist {
+		dist[i] = -1
+	}
+	s, ok := g.index[source]
+	if !ok {
+		return dist
+	}
+	dist[s] = 0
+	queue := []int32{s}
+	for head := 0; head < len(queue); head++ {
+		u := queue[head]
+		for _, v := range g.Neighbors(u) {
+			if dist[v] < 0 {
+				dist[v] = dist[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	return dist
+}
+
+// ComponentLabels labels every node index with the index of its connected
+// component and returns the labels together with the number of components.
+func (g *CSRGraph) ComponentLabels() ([]int32, int) {
+	labels := make([]int32, len(g.ids))
+	for i := range labels {
+		labels[i] = -1
+	}
+	count := int32(0)
+	queue := []int32{}
+	for s := range labels {
+		if labels[s] >= 0 {
+			continue
+		}
+		labels[s] = count
+		queue = append(queue[:0], int32(s))
+		for head := 0; head < len(queue); head++ {
+			for _, v := range g.Neighbors(queue[head]) {
+				if labels[v] < 0 {
+					labels[v] = count
+					queue = append(queue, v)
+				}
+			}
+		}
+		count++
+	}
+	return labels, int(count)
+}
+
+// PageRank computes the PageRank of every node by power iteration on the CSR
+// arrays, with uniform jumps as in PageRank. The rank of isolated nodes is
+// spread uniformly over all nodes.
+//
+// Parameters:
+//   - damping: The probability of following an edge instead of jumping, usually 0.85.
+//   - tolerance: The iteration stops once the L1 change of the ranks drops below it.
+//   - maxIterations: The maximum number of iterations.
+//
+// Returns:
+//
+//	The ranks indexed by node index, summing to 1.
+func (g *CSRGraph) PageRank(damping, tolerance float64, maxIterations int) []float64 {
+	n := len(g.ids)
+	if n == 0 {
+		return nil
+	}
+	uniform, _ := distribution[Node](g.ids, nil, "")
+	rank := make([]float64, n)
+	next := make([]float64, n)
+	copy(rank, uniform)
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		dangling := 0.0
+		for i := range next {
+			next[i] = 0
+		}
+		for u := 0; u < n; u++ {
+			degree := g.Degree(int32(u))
+			if degree == 0 {
+				dangling += rank[u]
+				continue
+			}
+			share := rank[u] / float64(degree)
+			for _, v := range g.Neighbors(int32(u)) {
+				next[v] += share
+			}
+		}
+		pageRankJumps(next, damping, dangling, uniform, uniform)
+		change := 0.0
+		for i := range next {
+			change += math.Abs(next[i] - rank[i])
+		}
+		rank, next = next, rank
+		if change < tolerance {
+			break
+		}
+	}
+	return rank
+}
+
+// RandomWalkSample samples the graph with a random walk with restart and
+// returns the subgraph induced by the visited nodes. When the walk gets stuck,
+// on an isolated node or without reaching new nodes for NumberOfNodes steps, it
+// restarts from a new random node.
+//
+// Parameters:
+//   - sampledGraphSizeRatio: The fraction of the nodes to keep.
+//   - restartProbability: The probability of returning to the start node at every step.
+//   - seed: The seed of the random number generator.
+//
+// References:
+//   - Leskovec, Jure, and Christos Faloutsos. "Sampling from large graphs."
+//     Proceedings of the 12th ACM SIGKDD international conference on Knowledge
+//     discovery and data mining. 2006.
+func (g *CSRGraph) RandomWalkSample(sampledGraphSizeRatio float32, restartProbability float64, seed int64) *UndirectedGraph {
+	n := len(g.ids)
+	target := int(float32(n) * sampledGraphSizeRatio)
+	if target > n {
+		target = n
+	}
+	rng := rand.New(rand.NewSource(seed))
+	visited := make([]bool, n)
+	order := []int32{}
+	visit := func(i int32) {
+		if !visited[i] {
+			visited[i] = true
+			order = append(order, i)
+		}
+	}
+
+	start := int32(-1)
+	current := int32(-1)
+	stale := 0
+	for len(order) < target {
+		if current < 0 || g.Degree(current) == 0 || stale > n {
+			start = int32(rng.Intn(n))
+			current = start
+			stale = 0
+		}
+		before := len(order)
+		visit(current)
+		if len(order) == before {
+			stale++
+		}
+		if g.Degree(current) == 0 || rng.Float64() < restartProbability {
+			current = start
+			continue
+		}
+		row := g.Neighbors(current)
+		current = row[rng.Intn(len(row))]
+	}
+
+	sample := &UndirectedGraph{
+		Nodes: make(map[Node]bool, len(order)),
+		Edges: make(map[Node][]Node, len(order)),
+	}
+	for _, u := range order {
+		sample.Nodes[g.ids[u]] = true
+		neighbors := []Node{}
+		for _, v := range g.Neighbors(u) {
+			if visited[v] {
+				neighbors = append(neighbors, g.ids[v])
+			}
+		}
+		sample.Edges[g.ids[u]] = neighbors
+	}
+	return sample
+}
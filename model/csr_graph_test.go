@@ -0,0 +1,94 @@
+package model
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCSRGraph_FromUndirected(t *testing.T) {
+	g := twoCliques()
+	g.AddNode(42)
+	csr := NewCSRGraph(g)
+
+	if csr.NumberOfNodes() != 11 || csr.NumberOfEdges() != g.NumberOfEdges() {
+		t.Errorf("Expected 11 nodes and %d edges, but got %d and %d", g.NumberOfEdges(), csr.NumberOfNodes(), csr.NumberOfEdges())
+	}
+	for node := range g.Nodes {
+		if csr.NodeDegree(node) != g.NodeDegree(node) {
+			t.Errorf("Expected degree %d of node %d, but got %d", g.NodeDegree(node), node, csr.NodeDegree(node))
+		}
+	}
+	if !csr.HasEdge(4, 5) || csr.HasEdge(0, 9) || csr.HasEdge(0, 100) {
+		t.Errorf("Unexpected edges in CSR graph")
+	}
+	if !csr.ToUndirected().Equals(g) {
+		t.Errorf("Expected the round trip to give back the original graph")
+	}
+
+	// Test case 2: The builder gives the same layout as the conversion
+	builder := NewCSRBuilder()
+	for _, edge := range g.GetEdgeTuples() {
+		// every edge is listed in both directions
+		if edge.Node1 < edge.Node2 {
+			builder.AddEdge(edge)
+		}
+	}
+	builder.AddNode(42)
+	built := builder.Build()
+	for i := int32(0); i < int32(csr.NumberOfNodes()); i++ {
+		if built.NodeAt(i) != csr.NodeAt(i) || !reflect.DeepEqual(built.Neighbors(i), csr.Neighbors(i)) {
+			t.Errorf("Expected row %d to be %v, but got %v", i, csr.Neighbors(i), built.Neighbors(i))
+		}
+	}
+}
+
+func TestCSRGraph_Traversal(t *testing.T) {
+	g := twoCliques()
+	g.AddEdge(Edge{Node1: 20, Node2: 21})
+	csr := NewCSRGraph(g)
+
+	if order := csr.BFS(0); len(order) != 10 || order[0] != 0 {
+		t.Errorf("Expected 10 nodes reachable from 0, but got %v", order)
+	}
+	dist := csr.Distances(0)
+	nine, _ := csr.IndexOf(9)
+	twenty, _ := csr.IndexOf(20)
+	if dist[nine] != 3 || dist[twenty] != -1 {
+		t.Errorf("Expected distances 3 and -1, but got %d and %d", dist[nine], dist[twenty])
+	}
+	if _, count := csr.ComponentLabels(); count != 2 {
+		t.Errorf("Expected 2 components, but got %d", count)
+	}
+}
+
+func TestCSRGraph_PageRankAndSample(t *testing.T) {
+	g := &UndirectedGraph{}
+	for i := 1; i <= 4; i++ {
+		g.AddEdge(Edge{Node1: 0, Node2: Node(i)})
+	}
+	csr := NewCSRGraph(g)
+
+	rank := csr.PageRank(0.85, 1e-10, 100)
+	sum := 0.0
+	for _, r := range rank {
+		sum += r
+	}
+	// a star has a closed form: the center gets (1-d)/n + d * Σ leaves
+	center := (1 - 0.85) / 5 * (1 + 4*0.85) / (1 - 0.85*0.85)
+	if math.Abs(sum-1) > 1e-9 || math.Abs(rank[0]-center) > 1e-6 {
+		t.Errorf("Expected center rank %v and sum 1, but got %v and %v", center, rank[0], sum)
+	}
+
+	sample := csr.RandomWalkSample(0.6, 0.15, 1)
+	if len(sample.Nodes) != 3 {
+		t.Errorf("Expected 3 sampled nodes, but got %d", len(sample.Nodes))
+	}
+	for node := range sample.Nodes {
+		for _, neighbor := range sample.Edges[node] {
+			if !sample.Nodes[neighbor] {
+				t.Errorf("Expected the sample to be an induced subgraph, found edge to %d", neighbor)
+			}
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DirectedGraph is a graph whose edges point from Edge.Node1 to Edge.Node2. It
+// keeps both the outgoing and the incoming adjacency lists, so successors and
+// predecessors of a node are available without scanning the graph.
+type DirectedGraph struct {
+	Nodes map[Node]bool
+	Out   map[Node][]Node
+	In    map[Node][]Node
+}
+
+var _ Graph = (*DirectedGraph)(nil)
+
+func (g *DirectedGraph) String() string {
+	var str strings.Builder
+
+	str.WriteString("Nodes:\n")
+	for node := range g.Nodes {
+		str.WriteString(fmt.Sprintf("%d: true\t", node))
+	}
+
+	str.WriteString("\nEdges:\n")
+	for node, successors := range g.Out {
+		str.WriteString(fmt.Sprintf("%d -> %v\n", node, successors))
+	}
+
+	return str.String()
+}
+
+// AddNode adds a node to the DirectedGraph if it does not already exist.
+func (g *DirectedGraph) AddNode(node Node) {
+	if g.Nodes == nil {
+		g.Nodes = make(map[Node]bool)
+	}
+	g.Nodes[node] = true
+}
+
+// AddNodes adds multiple nodes to the DirectedGraph.
+func (g *DirectedGraph) AddNodes(nodes []Node) {
+	for _, node := range nodes {
+		g.AddNode(node)
+	}
+}
+
+/*
+AddEdge adds a directed edge from edge.Node1 to edge.Node2.
+
+Parameters:
+- edge: An Edge struct whose Node1 is the source and Node2 the target of the edge.
+
+Description:
+The function ensures the existence of the adjacency maps, adds both nodes to the graph if they do not already exist and records the edge in the outgoing list of the source and the incoming list of the target.
+
+Example:
+
+	directedGraph := DirectedGraph{}
+	directedGraph.AddEdge(Edge{Node1: 1, Node2: 2})
+
+	fmt.Println(directedGraph.Out) // Output: map[1:[2]]
+	fmt.Println(directedGraph.In)  // Output: map[2:[1]]
+*/
+func (g *DirectedGraph) AddEdge(edge Edge) {
+	if g.Out == nil {
+		g.Out = make(map[Node][]Node)
+	}
+	if g.In == nil {
+		g.In = make(map[Node][]Node)
+	}
+
+	g.AddNode(edge.Node1)
+	g.AddNode(edge.Node2)
+
+	g.Out[edge.Node1] = append(g.Out[edge.Node1], edge.Node2)
+	g.In[edge.Node2] = append(g.In[edge.Node2], edge.Node1)
+}
+
+// Successors returns the nodes that the given node points to.
+func (g *DirectedGraph) Successors(node Node) []Node {
+	return g.Out[node]
+}
+
+// Predecessors returns the nodes that point to the given node.
+func (g *DirectedGraph) Predecessors(node Node) []Node {
+	return g.In[node]
+}
+
+// OutDegree returns the number of edges leaving the node.
+func (g *DirectedGraph) OutDegree(node Node) int {
+	return len(g.Out[node])
+}
+
+// InDegree returns the number of edges entering the node.
+func (g *DirectedGraph) InDegree(node Node) int {
+	return len(g.In[node])
+}
+
+// NodeDegree returns the total degree of the node, the sum of its in-degree and out-degree.
+func (g *DirectedGraph) NodeDegree(node Node) int {
+	if !g.Nodes[node] {
+		return 0
+	}
+	return g.InDegree(node) + g.OutDegree(node)
+}
+
+// NumberOfEdges returns the number of directed edges in the graph.
+func (g *DirectedGraph) NumberOfEdges() int {
+	totalEdges := 0
+	for _, successors := range g.Out {
+		totalEdges += len(successors)
+	}
+	return totalEdges
+}
+
+// HasNode checks if the DirectedGraph contains a specific node.
+func (g *DirectedGraph) HasNode(node Node) bool {
+	return g.Nodes[node]
+}
+
+// GetEdgeTuples returns every directed edge of the graph exactly once.
+func (g *DirectedGraph) GetEdgeTuples() []Edge {
+	var edges []Edge
+	for source, successors := range g.Out {
+		for _, target := range successors {
+			edges = append(edges, Edge{source, target})
+		}
+	}
+	return edges
+}
+
+// RemoveEdge removes the directed edge from edge.Node1 to edge.Node2. The
+// reverse edge, if present, is kept.
+func (g *DirectedGraph) RemoveEdge(edge Edge) {
+	if len(g.Out[edge.Node1]) > 0 {
+		g.Out[edge.Node1] = DeleteFromSlice(g.Out[edge.Node1], edge.Node2)
+	}
+	if len(g.In[edge.Node2]) > 0 {
+		g.In[edge.Node2] = DeleteFromSlice(g.In[edge.Node2], edge.Node1)
+	}
+}
+
+// RemoveNode removes a node and every edge entering or leaving it. Only the
+// adjacency lists of the node's neighbours are updated.
+func (g *DirectedGraph) RemoveNode(node Node) {
+	delete(g.Nodes, node)
+
+	for _, target := range g.Out[node] {
+		if target != node {
+			g.In[target] = DeleteFromSlice(g.In[target], node)
+		}
+	}
+	for _, source := range g.In[node] {
+		if source != node {
+			g.Out[source] = DeleteFromSlice(g.Out[source], node)
+		}
+	}
+
+	delete(g.Out, node)
+	delete(g.In, node)
+}
+
+// Reverse returns a new DirectedGraph with the direction of every edge flipped.
+func (g *DirectedGraph) Reverse() *DirectedGraph {
+	reversed := &DirectedGraph{}
+	for node := range g.Nodes {
+		reversed.AddNode(node)
+	}
+	for source, successors := range g.Out {
+		for _, target := range successors {
+			reversed.AddEdge(Edge{Node1: target, Node2: source})
+		}
+	}
+	return reversed
+}
+
+// ToUndirected returns the underlying UndirectedGraph, in which two nodes are
+// connected if there is an edge between them in either direction. Reciprocal
+// edges become a single undirected edge.
+func (g *DirectedGraph) ToUndirected() *UndirectedGraph {
+	undirected := &UndirectedGraph{
+		Nodes: make(map[Node]bool, len(g.Nodes)),
+		Edges: make(map[Node][]Node),
+	}
+	for node := range g.Nodes {
+		undirected.AddNode(node)
+	}
+
+	added := make(map[Edge]bool)
+	for source, successors := range g.Out {
+		for _, target := range successors {
+			key := Edge{source, target}
+			if target < source {
+				key = Edge{target, source}
+			}
+			if added[key] {
+				continue
+			}
+			added[key] = true
+			undirected.AddEdge(key)
+		}
+	}
+	return undirected
+}
+
+// Sample runs the sampler on the undirected version of the graph, since the
+// sampling strategies work on UndirectedGraph.
+func (g *DirectedGraph) Sample(sampler ISamplingStrategy, ratioNodesToDelete float32) (*UndirectedGraph, error) {
+	return sampler.Sample(g.ToUndirected(), ratioNodesToDelete)
+}
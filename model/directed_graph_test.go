@@ -0,0 +1,84 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func citationGraph() *DirectedGraph {
+	g := &DirectedGraph{}
+	edges := []Edge{
+		{Node1: 1, Node2: 2},
+		{Node1: 1, Node2: 3},
+		{Node1: 2, Node2: 3},
+		{Node1: 3, Node2: 1},
+	}
+	for _, edge := range edges {
+		g.AddEdge(edge)
+	}
+	return g
+}
+
+func TestDirectedGraph_AddEdge(t *testing.T) {
+	g := citationGraph()
+
+	expectedOut := map[Node][]Node{1: {2, 3}, 2: {3}, 3: {1}}
+	expectedIn := map[Node][]Node{1: {3}, 2: {1}, 3: {1, 2}}
+	if !reflect.DeepEqual(g.Out, expectedOut) {
+		t.Errorf("Expected %v, but got %v", expectedOut, g.Out)
+	}
+	if !reflect.DeepEqual(g.In, expectedIn) {
+		t.Errorf("Expected %v, but got %v", expectedIn, g.In)
+	}
+
+	if g.OutDegree(1) != 2 || g.InDegree(1) != 1 || g.NodeDegree(1) != 3 {
+		t.Errorf("Unexpected degrees for node 1: out %d, in %d, total %d", g.OutDegree(1), g.InDegree(1), g.NodeDegree(1))
+	}
+	if g.NumberOfEdges() != 4 {
+		t.Errorf("Expected 4 edges, but got %d", g.NumberOfEdges())
+	}
+}
+
+func TestDirectedGraph_RemoveNode(t *testing.T) {
+	g := citationGraph()
+	g.RemoveNode(3)
+
+	expectedOut := map[Node][]Node{1: {2}, 2: {}}
+	expectedIn := map[Node][]Node{1: {}, 2: {1}}
+	if !reflect.DeepEqual(g.Out, expectedOut) {
+		t.Errorf("Expected %v, but got %v", expectedOut, g.Out)
+	}
+	if !reflect.DeepEqual(g.In, expectedIn) {
+		t.Errorf("Expected %v, but got %v", expectedIn, g.In)
+	}
+	if g.HasNode(3) {
+		t.Errorf("Expected node 3 to be removed")
+	}
+
+	// Test case 2: Removing an edge keeps the reverse direction
+	g = citationGraph()
+	g.RemoveEdge(Edge{Node1: 1, Node2: 3})
+	if !reflect.DeepEqual(g.Successors(3), []Node{1}) || !reflect.DeepEqual(g.Successors(1), []Node{2}) {
+		t.Errorf("Unexpected successors after removal: %v", g.Out)
+	}
+}
+
+func TestDirectedGraph_ReverseAndToUndirected(t *testing.T) {
+	g := citationGraph()
+	reversed := g.Reverse()
+	if !reflect.DeepEqual(reversed.Successors(3), []Node{1, 2}) && !reflect.DeepEqual(reversed.Successors(3), []Node{2, 1}) {
+		t.Errorf("Expected successors [1 2] of node 3, but got %v", reversed.Successors(3))
+	}
+	if reversed.NumberOfEdges() != g.NumberOfEdges() {
+		t.Errorf("Expected %d edges, but got %d", g.NumberOfEdges(), reversed.NumberOfEdges())
+	}
+
+	undirected := g.ToUndirected()
+	expected := &UndirectedGraph{
+		Nodes: map[Node]bool{1: true, 2: true, 3: true},
+		Edges: map[Node][]Node{1: {2, 3}, 2: {1, 3}, 3: {1, 2}},
+	}
+	if !undirected.Equals(expected) {
+		t.Errorf("Expected %v, but got %v", expected, undirected)
+	}
+}
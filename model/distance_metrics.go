@@ -0,0 +1,213 @@
+package model
+
+import (
+	"math"
+	"runtime"
+)
+
+// DistanceMatrix holds the length of the shortest path between every ordered
+// pair of nodes of a graph, or +Inf if there is no path. Row i holds the
+// distances from Nodes[i].
+type DistanceMatrix[K comparable] struct {
+	Nodes []K
+	Data  []float64
+	index map[K]int
+}
+
+func newDistanceMatrix[K comparable](nodes []K) *DistanceMatrix[K] {
+	m := &DistanceMatrix[K]{
+		Nodes: nodes,
+		Data:  make([]float64, len(nodes)*len(nodes)),
+		index: make(map[K]int, len(nodes)),
+	}
+	for i, node := range nodes {
+		m.index[node] = i
+	}
+	for i := range m.Data {
+		m.Data[i] = math.Inf(1)
+	}
+	for i := range nodes {
+		m.Data[i*len(nodes)+i] = 0
+	}
+	return m
+}
+
+// Row returns the distances from the i-th node. The slice shares memory with
+// the matrix.
+func (m *DistanceMatrix[K]) Row(i int) []float64 {
+	n := len(m.Nodes)
+	return m.Data[i*n : (i+1)*n]
+}
+
+// Distance returns the length of the shortest path from u to v, and whether v
+// can be reached from u.
+func (m *DistanceMatrix[K]) Distance(u, v K) (float64, bool) {
+	i, okU := m.index[u]
+	j, okV := m.index[v]
+	if !okU || !okV {
+		return math.Inf(1), false
+	}
+	distance := m.Data[i*len(m.Nodes)+j]
+	return distance, !math.IsInf(distance, 1)
+}
+
+/*
+AllPairsShortestPaths computes the distances between all pairs of nodes by running a single-source
+search from every node, spread over several goroutines.
+
+Parameters:
+- g: The graph. Directed graphs are searched along the direction of their edges.
+- weight: The non-negative weight of every edge, or nil to measure distances in number of edges. With
+nil, breadth-first search is used, otherwise Dijkstra's algorithm.
+- workers: The number of goroutines, or 0 to use one per CPU.
+
+Returns:
+- *DistanceMatrix[K]: The distances, in the order of g.NodeIDs().
+- error: An error if an edge has a negative weight.
+*/
+func AllPairsShortestPaths[K comparable](g GraphView[K], weight WeightFunc[K], workers int) (*DistanceMatrix[K], error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	// the goroutines only read a snapshot, so neither the graph nor the weight
+	// function has to be safe for concurrent use
+	a := newIndexedAdjacency(g, weight)
+	if err := a.checkNonNegative(); err != nil {
+		return nil, err
+	}
+
+	m := newDistanceMatrix(a.nodes)
+	forEachSource(allIndices(len(a.nodes)), workers, func(_, source int) {
+		copy(m.Row(source), a.search(source, weight != nil).distances)
+	})
+	return m, nil
+}
+
+/*
+FloydWarshall computes the distances between all pairs of nodes in O(n³) time and O(n²) memory, which
+suits small dense graphs. Edge weights may be negative.
+
+Parameters:
+- g: The graph. Directed graphs are searched along the direction of their edges.
+- weight: The weight of every edge, or nil to give every edge weight 1.
+
+Returns:
+- *DistanceMatrix[K]: The distances, in the order of g.NodeIDs().
+- error: ErrNegativeCycle if the graph has a negative cycle.
+
+References:
+  - Floyd, R. W. (1962). Algorithm 97: Shortest path. Communications of the ACM, 5(6), 345.
+*/
+func FloydWarshall[K comparable](g GraphView[K], weight WeightFunc[K]) (*DistanceMatrix[K], error) {
+	if weight == nil {
+		weight = unitWeight[K]
+	}
+	m := newDistanceMatrix(g.NodeIDs())
+	n := len(m.Nodes)
+	for i, u := range m.Nodes {
+		row := m.Row(i)
+		for _, v := range g.NeighborIDs(u) {
+			j := m.index[v]
+			row[j] = min(row[j], weight(u, v))
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		rowK := m.Row(k)
+		for i := 0; i < n; i++ {
+			row := m.Row(i)
+			throughK := row[k]
+			if math.IsInf(throughK, 1) {
+				continue
+			}
+			for j, distance := range rowK {
+				if throughK+distance < row[j] {
+					row[j] = throughK + distance
+				}
+			}
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if m.Data[i*n+i] < 0 {
+			return nil, ErrNegativeCycle
+		}
+	}
+	return m, nil
+}
+
+// Eccentricities returns the eccentricity of every node, the largest distance
+// from it to any other node. It is +Inf for nodes from which some node cannot
+// be reached.
+func (m *DistanceMatrix[K]) Eccentricities() map[K]float64 {
+	eccentricities := make(map[K]float64, len(m.Nodes))
+	for i, node := range m.Nodes {
+		eccentricity := 0.0
+		for _, distance := range m.Row(i) {
+			eccentricity = max(eccentricity, distance)
+		}
+		eccentricities[node] = eccentricity
+	}
+	return eccentricities
+}
+
+// Diameter returns the largest eccentricity, or +Inf if the graph is not
+// (strongly) connected.
+func (m *DistanceMatrix[K]) Diameter() float64 {
+	diameter := 0.0
+	for _, eccentricity := range m.Eccentricities() {
+		diameter = max(diameter, eccentricity)
+	}
+	return diameter
+}
+
+// Radius returns the smallest eccentricity, or +Inf if no node reaches all
+// others.
+func (m *DistanceMatrix[K]) Radius() float64 {
+	radius := math.Inf(1)
+	for _, eccentricity := range m.Eccentricities() {
+		radius = min(radius, eccentricity)
+	}
+	return radius
+}
+
+// Center returns the nodes whose eccentricity equals the radius.
+func (m *DistanceMatrix[K]) Center() []K {
+	return m.nodesWithEccentricity(m.Radius())
+}
+
+// Periphery returns the nodes whose eccentricity equals the diameter.
+func (m *DistanceMatrix[K]) Periphery() []K {
+	return m.nodesWithEccentricity(m.Diameter())
+}
+
+func (m *DistanceMatrix[K]) nodesWithEccentricity(value float64) []K {
+	eccentricities := m.Eccentricities()
+	nodes := []K{}
+	for _, node := range m.Nodes {
+		if eccentricities[node] == value {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// AverageShortestPathLength returns the mean distance between ordered pairs of
+// distinct nodes. Pairs without a path are left out, so the value is also
+// defined for disconnected graphs, such as samples; it is 0 if no node reaches
+// another.
+func (m *DistanceMatrix[K]) AverageShortestPathLength() float64 {
+	total, pairs := 0.0, 0
+	for i := range m.Nodes {
+		for j, distance := range m.Row(i) {
+			if i != j && !math.IsInf(distance, 1) {
+				total += distance
+				pairs++
+			}
+		}
+	}
+	if pairs == 0 {
+		return 0
+	}
+	return total / float64(pairs)
+}
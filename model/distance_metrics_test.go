@@ -0,0 +1,101 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestAllPairsShortestPaths(t *testing.T) {
+	// a path 0-1-2-3-4 with a pendant node 5 attached to 2
+	g := PathGraph(5)
+	g.AddEdge(Edge{Node1: 2, Node2: 5})
+
+	bfs, err := AllPairsShortestPaths[Node](g, nil, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	floyd, _ := FloydWarshall[Node](g, nil)
+	for _, u := range bfs.Nodes {
+		for _, v := range bfs.Nodes {
+			d1, _ := bfs.Distance(u, v)
+			d2, _ := floyd.Distance(u, v)
+			if d1 != d2 {
+				t.Errorf("Expected %v, but got %v for %d-%d", d1, d2, u, v)
+			}
+		}
+	}
+
+	if bfs.Diameter() != 4 || bfs.Radius() != 2 || bfs.Eccentricities()[5] != 3 {
+		t.Errorf("Expected diameter 4 and radius 2, but got %v and %v", bfs.Diameter(), bfs.Radius())
+	}
+	if center := bfs.Center(); !reflect.DeepEqual(center, []Node{2}) {
+		t.Errorf("Expected %v, but got %v", []Node{2}, center)
+	}
+	periphery := bfs.Periphery()
+	sort.Slice(periphery, func(i, j int) bool { return periphery[i] < periphery[j] })
+	if !reflect.DeepEqual(periphery, []Node{0, 4}) {
+		t.Errorf("Expected %v, but got %v", []Node{0, 4}, periphery)
+	}
+	// 15 unordered pairs with distances summing to 31
+	if !almostEqual(bfs.AverageShortestPathLength(), 31.0/15) {
+		t.Errorf("Expected %v, but got %v", 31.0/15, bfs.AverageShortestPathLength())
+	}
+
+	// Test case 2: Disconnected graphs
+	g.AddNode(9)
+	disconnected, _ := AllPairsShortestPaths[Node](g, nil, 0)
+	if _, ok := disconnected.Distance(0, 9); ok || !math.IsInf(disconnected.Diameter(), 1) || !almostEqual(disconnected.AverageShortestPathLength(), 31.0/15) {
+		t.Errorf("Expected an infinite diameter, but got %v", disconnected.Diameter())
+	}
+}
+
+func TestWeightedAllPairsShortestPaths(t *testing.T) {
+	g := weightedDiamond()
+	dijkstra, err := AllPairsShortestPaths[Node](g, g.WeightFunc(), 0)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	floyd, _ := FloydWarshall[Node](g, g.WeightFunc())
+	if d, _ := dijkstra.Distance(2, 1); d != 3 {
+		t.Errorf("Expected 3, but got %v", d)
+	}
+	if !reflect.DeepEqual(dijkstra.Eccentricities(), floyd.Eccentricities()) {
+		t.Errorf("Expected %v, but got %v", dijkstra.Eccentricities(), floyd.Eccentricities())
+	}
+
+	// Test case 2: Negative weights
+	g.AddWeightedEdge(Edge{Node1: 0, Node2: 1}, -1)
+	if _, err := AllPairsShortestPaths[Node](g, g.WeightFunc(), 2); err == nil {
+		t.Errorf("Expected an error for a negative weight")
+	}
+	if _, err := FloydWarshall[Node](g, g.WeightFunc()); !errors.Is(err, ErrNegativeCycle) {
+		t.Errorf("Expected %v, but got %v", ErrNegativeCycle, err)
+	}
+}
+
+func TestAllPairsShortestPathsNewGraph(t *testing.T) {
+	g := starNewGraph()
+	m, err := AllPairsShortestPaths[string](&g, NewGraphWeight(&g, "weight"), 4)
+	if err != nil || m.Diameter() != 2 || !reflect.DeepEqual(m.Center(), []string{"a"}) {
+		t.Errorf("Expected diameter 2 and center [a], but got %v and %v", m.Diameter(), m.Center())
+	}
+}
+
+func TestAllPairsShortestPathsConcurrentWeights(t *testing.T) {
+	// the weight function builds the lazy edge index of the graph on first use
+	g := CycleGraph(8)
+	g.ResetEdgeIndex()
+	weight := func(u, v Node) float64 {
+		if g.HasEdge(u, v) {
+			return 2
+		}
+		return math.Inf(1)
+	}
+	m, err := AllPairsShortestPaths[Node](g, weight, 4)
+	if err != nil || m.Diameter() != 8 {
+		t.Errorf("Expected diameter 8, but got %v (%v)", m.Diameter(), err)
+	}
+}
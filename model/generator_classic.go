@@ -263,4 +263,56 @@ func CirculantGraph(numberOfNodes int, offset int) *UndirectedGraph {
 	return g
 }
 
+// GridGraph returns the two-dimensional grid graph with the given number of rows and columns, the
+// Cartesian product of two path graphs.
+//
+// Parameters:
+//
+//	rows: The number of rows of the grid.
+//	columns: The number of columns of the grid.
+//
+// Returns:
+//
+//	An UndirectedGraph in which the node in row r and column c, counted from 0, is r*columns + c.
+//
+// Example:
+//
+//	// Generate a 3x4 grid with 12 nodes and 17 edges
+//	graph := GridGraph(3, 4)
+func GridGraph(rows int, columns int) *UndirectedGraph {
+	g, _ := CartesianProduct(pathFactor(columns), pathFactor(rows))
+	return g
+}
+
+// TorusGraph returns the two-dimensional torus with the given number of rows and columns (each at
+// least 3), the Cartesian product of two cycle graphs. Nodes are numbered as in GridGraph.
+func TorusGraph(rows int, columns int) (*UndirectedGraph, error) {
+	if rows < 3 || columns < 3 {
+		return nil, fmt.Errorf("rows and columns must be at least 3")
+	}
+	g, _ := CartesianProduct(CycleGraph(columns), CycleGraph(rows))
+	return g, nil
+}
+
+// HypercubeGraph returns the hypercube graph Q_d of the given dimension, the Cartesian product of d
+// copies of the complete graph on two nodes. It has 2^d nodes, which are connected if their labels
+// differ in exactly one bit.
+func HypercubeGraph(dimension int) *UndirectedGraph {
+	g := TrivialGraph()
+	for i := 0; i < dimension; i++ {
+		g, _ = CartesianProduct(g, CompleteGraph(2))
+	}
+	return g
+}
+
+// pathFactor returns the path graph with the given number of nodes, including
+// the single node of a path of length 0.
+func pathFactor(numberOfNodes int) *UndirectedGraph {
+	g := PathGraph(numberOfNodes)
+	if numberOfNodes > 0 {
+		g.AddNode(0)
+	}
+	return g
+}
+
 // TODO: balanced tree, binomial tree, barbell graph, complete multipartite graph, dorogovtsev goltsev mendes graph, full rary tree
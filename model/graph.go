@@ -26,7 +26,34 @@ type Edge struct {
 
 type UndirectedGraph struct {
 	Nodes map[Node]bool
+	// Edges holds the adjacency lists. After writing to it directly rather than
+	// through the methods of the graph, call ResetEdgeIndex so that HasEdge does
+	// not answer from a stale index.
 	Edges map[Node][]Node
+
+	// DisallowMultiEdges and DisallowSelfLoops set the adjacency policy
+	// respected by AddEdge, ContractNode and ContractEdge. The zero value keeps
+	// parallel edges and self-loops; NewSimpleGraph returns a graph that
+	// ignores both.
+	DisallowMultiEdges bool
+	DisallowSelfLoops  bool
+
+	// adjacency counts the entries of every ordered pair of nodes in Edges, so
+	// that HasEdge runs in O(1). It is only built by HasEdge, which AddEdge
+	// calls when DisallowMultiEdges is set, and is then kept up to date by the
+	// methods of the graph.
+	adjacency map[Edge]int
+}
+
+// NewSimpleGraph returns an empty UndirectedGraph in which AddEdge,
+// ContractNode and ContractEdge never create parallel edges or self-loops.
+func NewSimpleGraph() *UndirectedGraph {
+	return &UndirectedGraph{
+		Nodes:              make(map[Node]bool),
+		Edges:              make(map[Node][]Node),
+		DisallowMultiEdges: true,
+		DisallowSelfLoops:  true,
+	}
 }
 
 type Components struct {
@@ -113,6 +140,7 @@ Parameters:
 
 Description:
 The function ensures the existence of the Edges map in the UndirectedGraph and adds the specified edge. It also adds both connected nodes to the graph if they do not already exist.
+If DisallowMultiEdges is set, an edge that already exists is not added again, and if DisallowSelfLoops is set, self-loops are not added.
 
 Example:
 
@@ -127,18 +155,69 @@ Example:
 	fmt.Println(undirectedGraph.Edges) // Output: map[1:[2] 2:[1]]
 */
 func (g *UndirectedGraph) AddEdge(edge Edge) {
+	// Add both connected nodes to the graph
+	g.AddNode(edge.Node1)
+	g.AddNode(edge.Node2)
+
+	// Respect the adjacency policy of the graph
+	if edge.Node1 == edge.Node2 && g.DisallowSelfLoops {
+		return
+	}
+	if g.DisallowMultiEdges && g.HasEdge(edge.Node1, edge.Node2) {
+		return
+	}
+
+	g.appendEdge(edge)
+}
+
+// appendEdge adds the edge to the adjacency lists regardless of the policy.
+func (g *UndirectedGraph) appendEdge(edge Edge) {
 	// Ensure the existence of the Edges map
 	if g.Edges == nil {
 		g.Edges = make(map[Node][]Node)
 	}
-
-	// Add both connected nodes to the graph
 	g.AddNode(edge.Node1)
 	g.AddNode(edge.Node2)
-
-	// Add the edge to the Edges map
 	g.Edges[edge.Node1] = append(g.Edges[edge.Node1], edge.Node2)
 	g.Edges[edge.Node2] = append(g.Edges[edge.Node2], edge.Node1)
+	if g.adjacency != nil {
+		g.adjacency[edge]++
+		g.adjacency[Edge{edge.Node2, edge.Node1}]++
+	}
+}
+
+/*
+HasEdge checks if the nodes u and v are connected.
+
+Description:
+The first call indexes the adjacency lists, after which every call takes constant time. The
+index is kept up to date by the methods of the graph; if Edges is modified directly afterwards,
+call ResetEdgeIndex.
+*/
+func (g *UndirectedGraph) HasEdge(u, v Node) bool {
+	if g.adjacency == nil {
+		g.adjacency = make(map[Edge]int)
+		for node, neighbors := range g.Edges {
+			for _, neighbor := range neighbors {
+				g.adjacency[Edge{node, neighbor}]++
+			}
+		}
+	}
+	return g.adjacency[Edge{u, v}] > 0
+}
+
+// ResetEdgeIndex discards the index used by HasEdge, which is rebuilt from
+// Edges on the next call.
+func (g *UndirectedGraph) ResetEdgeIndex() {
+	g.adjacency = nil
+}
+
+// unindexEdge removes every edge between u and v from the index of HasEdge.
+func (g *UndirectedGraph) unindexEdge(u, v Node) {
+	if g.adjacency != nil {
+		delete(g.adjacency, Edge{u, v})
+		delete(g.adjacency, Edge{v, u})
+	}
 }
 
 func (g *UndirectedGraph) DFS(startNode Node) *UndirectedGraph {
@@ -369,6 +448,8 @@ func (g *UndirectedGraph) RemoveEdge(edge Edge) {
 	if len(g.Edges[edge.Node2]) > 0 {
 		g.Edges[edge.Node2] = DeleteFromSlice(g.Edges[edge.Node2], edge.Node1)
 	}
+
+	g.unindexEdge(edge.Node1, edge.Node2)
 }
 
 /*
@@ -379,6 +460,7 @@ Parameters:
 
 Description:
 The function removes the specified node from the Nodes map of the UndirectedGraph, as well as all edges connected to the node in the Edges map. This operation effectively disconnects the node and eliminates all edges involving that node.
+Only the adjacency lists of the node's neighbors are updated, so the cost is proportional to their degrees rather than to the size of the graph.
 
 Example:
 
@@ -405,36 +487,121 @@ func (g *UndirectedGraph) RemoveNode(node Node) {
 	// Remove the node from the Nodes map
 	delete(g.Nodes, node)
 
-	// Remove the node from the Edges map and update neighbors' adjacency lists
-	for neighbor, edges := range g.Edges {
-		g.Edges[neighbor] = DeleteFromSlice(edges, node)
-	}
+	// Only the adjacency lists of the neighbors can contain the node
+	g.detachNode(node)
 
 	// Delete the entry for the removed node from the Edges map
 	delete(g.Edges, node)
 }
 
-func (g *UndirectedGraph) ContractNode(node Node) {
-	neighbors := g.Edges[node]
-	for i := 0; i < len(neighbors); i++ {
-		for j := i + 1; j < len(neighbors); j++ {
-			g.Edges[neighbors[i]] = append(g.Edges[neighbors[i]], neighbors[j])
-			g.Edges[neighbors[j]] = append(g.Edges[neighbors[j]], neighbors[i])
+// detachNode removes the node from the adjacency lists of its neighbors, in
+// time proportional to their degrees.
+func (g *UndirectedGraph) detachNode(node Node) {
+	for _, neighbor := range g.Edges[node] {
+		if neighbor != node && containsNode(g.Edges[neighbor], node) {
+			g.Edges[neighbor] = DeleteFromSlice(g.Edges[neighbor], node)
 		}
+		g.unindexEdge(node, neighbor)
 	}
+}
 
-	// Remove the node from the Nodes map
-	delete(g.Nodes, node)
+/*
+RemoveNodes removes several nodes from the UndirectedGraph and all associated edges.
+
+Parameters:
+- nodes: The nodes to be removed. Nodes that are not in the graph are ignored.
 
-	// Remove the node from the Edges map and update neighbors' adjacency lists
-	for neighbor, edges := range g.Edges {
-		g.Edges[neighbor] = DeleteFromSlice(edges, node)
+Description:
+The adjacency list of every remaining neighbor is rewritten once, however many of its
+neighbors are removed, so the cost is proportional to the degrees of the removed nodes
+and their neighbors rather than to the size of the graph.
+*/
+func (g *UndirectedGraph) RemoveNodes(nodes []Node) {
+	removed := make(map[Node]bool, len(nodes))
+	for _, node := range nodes {
+		removed[node] = true
 	}
 
-	// Delete the entry for the removed node from the Edges map
-	delete(g.Edges, node)
+	affected := make(map[Node]bool)
+	for node := range removed {
+		for _, neighbor := range g.Edges[node] {
+			if !removed[neighbor] {
+				affected[neighbor] = true
+			}
+			g.unindexEdge(node, neighbor)
+		}
+		delete(g.Nodes, node)
+		delete(g.Edges, node)
+	}
+
+	for neighbor := range affected {
+		kept := []Node{}
+		for _, n := range g.Edges[neighbor] {
+			if !removed[n] {
+				kept = append(kept, n)
+			}
+		}
+		g.Edges[neighbor] = kept
+	}
 }
 
+/*
+RemoveEdges removes several undirected edges from the UndirectedGraph.
+
+Parameters:
+- edges: The edges to be removed. As with RemoveEdge, all parallel edges between the two
+nodes of an edge are removed.
+
+Description:
+Each affected adjacency list is rewritten once, so the cost is proportional to the
+degrees of the nodes of the removed edges.
+*/
+func (g *UndirectedGraph) RemoveEdges(edges []Edge) {
+	removed := make(map[Node]map[Node]bool)
+	mark := func(node1, node2 Node) {
+		if removed[node1] == nil {
+			removed[node1] = make(map[Node]bool)
+		}
+		removed[node1][node2] = true
+	}
+	for _, edge := range edges {
+		mark(edge.Node1, edge.Node2)
+		mark(edge.Node2, edge.Node1)
+		g.unindexEdge(edge.Node1, edge.Node2)
+	}
+
+	for node, targets := range removed {
+		if len(g.Edges[node]) == 0 {
+			continue
+		}
+		kept := []Node{}
+		for _, neighbor := range g.Edges[node] {
+			if !targets[neighbor] {
+				kept = append(kept, neighbor)
+			}
+		}
+		g.Edges[node] = kept
+	}
+}
+
+// ContractNode removes the node and connects each pair of its neighbours,
+// following the adjacency policy of the graph.
+func (g *UndirectedGraph) ContractNode(node Node) {
+	neighbors := append([]Node(nil), g.Edges[node]...)
+	for i := 0; i < len(neighbors); i++ {
+		for j := i + 1; j < len(neighbors); j++ {
+			if neighbors[i] != node && neighbors[j] != node {
+				g.AddEdge(Edge{Node1: neighbors[i], Node2: neighbors[j]})
+			}
+		}
+	}
+
+	g.RemoveNode(node)
+}
+
+// ContractEdge merges edge.Node1 into edge.Node2: the neighbours of Node1
+// become neighbours of Node2, following the adjacency policy of the graph, and
+// Node1 is removed.
 func (g *UndirectedGraph) ContractEdge(edge Edge) {
 	node1 := edge.Node1
 	node2 := edge.Node2
@@ -447,16 +614,7 @@ func (g *UndirectedGraph) ContractEdge(edge Edge) {
 		})
 	}
 
-	// Remove the node from the Nodes map
-	delete(g.Nodes, node1)
-
-	// Remove the node from the Edges map and update neighbors' adjacency lists
-	for neighbor, edges := range g.Edges {
-		g.Edges[neighbor] = DeleteFromSlice(edges, node1)
-	}
-
-	// Delete the entry for the removed node from the Edges map
-	delete(g.Edges, node1)
+	g.RemoveNode(node1)
 }
 
 // ConnectedComponents finds the connected components in an undirected graph.
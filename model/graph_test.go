@@ -629,3 +629,135 @@ func TestConnectedComponents(t *testing.T) {
 		})
 	}
 }
+
+func TestUndirectedGraph_RemoveNodes(t *testing.T) {
+	graph := twoCliques()
+	graph.RemoveNodes([]Node{0, 1, 4, 42})
+
+	expectedEdges := map[Node][]Node{
+		2: {3},
+		3: {2},
+	}
+	for node, neighbors := range expectedEdges {
+		if !reflect.DeepEqual(graph.Edges[node], neighbors) {
+			t.Errorf("Expected %v, but got %v", neighbors, graph.Edges[node])
+		}
+	}
+	if graph.NumberOfNodes() != 7 || graph.NumberOfEdges() != 11 {
+		t.Errorf("Expected 7 nodes and 11 edges, but got %d and %d", graph.NumberOfNodes(), graph.NumberOfEdges())
+	}
+	if !reflect.DeepEqual(graph.Edges[5], []Node{6, 7, 8, 9}) {
+		t.Errorf("Expected %v, but got %v", []Node{6, 7, 8, 9}, graph.Edges[5])
+	}
+}
+
+func TestUndirectedGraph_RemoveEdges(t *testing.T) {
+	graph := UndirectedGraph{
+		Nodes: map[Node]bool{1: true, 2: true, 3: true},
+		Edges: map[Node][]Node{
+			1: {2, 3},
+			2: {1, 3},
+			3: {1, 2},
+		},
+	}
+	graph.RemoveEdges([]Edge{{Node1: 1, Node2: 2}, {Node1: 3, Node2: 2}, {Node1: 3, Node2: 4}})
+
+	expectedEdges := map[Node][]Node{
+		1: {3},
+		2: {},
+		3: {1},
+	}
+	if !reflect.DeepEqual(graph.Edges, expectedEdges) {
+		t.Errorf("Expected %v, but got %v", expectedEdges, graph.Edges)
+	}
+}
+
+func TestUndirectedGraph_ContractNodeTouchesOnlyNeighbors(t *testing.T) {
+	graph := UndirectedGraph{}
+	graph.AddEdgesFromIntTupleList([][2]int{{1, 2}, {2, 3}, {4, 5}})
+	isolated := graph.Edges[4]
+	graph.ContractNode(2)
+
+	expectedEdges := map[Node][]Node{
+		1: {3},
+		3: {1},
+		4: {5},
+		5: {4},
+	}
+	if !reflect.DeepEqual(graph.Edges, expectedEdges) {
+		t.Errorf("Expected %v, but got %v", expectedEdges, graph.Edges)
+	}
+	// the adjacency lists of other nodes are not rewritten
+	if &graph.Edges[4][0] != &isolated[0] {
+		t.Errorf("Expected the adjacency list of 4 to be left untouched")
+	}
+}
+
+func TestUndirectedGraph_AdjacencyPolicy(t *testing.T) {
+	// Test case 1: A simple graph ignores parallel edges and self-loops
+	simple := NewSimpleGraph()
+	simple.AddEdgesFromIntTupleList([][2]int{{1, 2}, {2, 1}, {3, 3}})
+	if simple.NumberOfEdges() != 1 || simple.NodeDegree(1) != 1 || !simple.HasNode(3) {
+		t.Errorf("Expected 1 edge and degree 1, but got %d and %d", simple.NumberOfEdges(), simple.NodeDegree(1))
+	}
+	if !simple.HasEdge(2, 1) || simple.HasEdge(3, 3) {
+		t.Errorf("Expected only the edge 1-2, but got %v", simple.Edges)
+	}
+
+	// Test case 2: By default, the graph keeps everything
+	multi := UndirectedGraph{}
+	multi.AddEdgesFromIntTupleList([][2]int{{1, 2}, {2, 1}, {3, 3}})
+	// without the policy, adding edges does not build the index of HasEdge
+	if multi.adjacency != nil {
+		t.Errorf("Expected no edge index before calling HasEdge")
+	}
+	if multi.NumberOfEdges() != 3 || multi.NodeDegree(3) != 2 || !multi.HasEdge(3, 3) {
+		t.Errorf("Expected 3 edges and degree 2, but got %d and %d", multi.NumberOfEdges(), multi.NodeDegree(3))
+	}
+
+	// Test case 3: HasEdge follows removals
+	multi.RemoveEdge(Edge{Node1: 2, Node2: 1})
+	multi.RemoveNode(3)
+	if multi.HasEdge(1, 2) || multi.HasEdge(3, 3) || multi.NumberOfEdges() != 0 {
+		t.Errorf("Expected no edges, but got %v", multi.Edges)
+	}
+
+	// Test case 4: Writing to Edges directly requires ResetEdgeIndex
+	multi.Edges[1] = append(multi.Edges[1], 4)
+	multi.Edges[4] = append(multi.Edges[4], 1)
+	multi.ResetEdgeIndex()
+	if !multi.HasEdge(4, 1) {
+		t.Errorf("Expected the edge 1-4 after ResetEdgeIndex, but got %v", multi.Edges)
+	}
+}
+
+func TestUndirectedGraph_ContractionPolicy(t *testing.T) {
+	// contracting the middle of a triangle with a pendant node
+	graph := NewSimpleGraph()
+	graph.AddEdgesFromIntTupleList([][2]int{{1, 2}, {2, 3}, {1, 3}, {3, 4}})
+	graph.ContractNode(3)
+	expectedEdges := map[Node][]Node{
+		1: {2, 4},
+		2: {1, 4},
+		4: {1, 2},
+	}
+	for node, neighbors := range expectedEdges {
+		if !reflect.DeepEqual(sortedNodes(graph.Edges[node]), neighbors) {
+			t.Errorf("Expected %v, but got %v", neighbors, graph.Edges[node])
+		}
+	}
+
+	// Test case 2: Contracting an edge neither duplicates edges nor creates a self-loop
+	graph.ContractEdge(Edge{Node1: 1, Node2: 2})
+	if graph.NumberOfEdges() != 1 || !graph.HasEdge(2, 4) || graph.HasEdge(2, 2) {
+		t.Errorf("Expected the single edge 2-4, but got %v", graph.Edges)
+	}
+
+	// Test case 3: With self-loops allowed, the contracted edge becomes a loop
+	loops := UndirectedGraph{DisallowMultiEdges: true}
+	loops.AddEdge(Edge{Node1: 1, Node2: 2})
+	loops.ContractEdge(Edge{Node1: 1, Node2: 2})
+	if !loops.HasEdge(2, 2) || loops.NodeDegree(2) != 2 {
+		t.Errorf("Expected a self-loop on 2, but got %v", loops.Edges)
+	}
+}
@@ -0,0 +1,425 @@
+package model
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// GraphView is the read-only view shared by all graph types, with node keys of
+// type K: Node for UndirectedGraph, DirectedGraph and CSRGraph, string for
+// NewGraph and any comparable type for KeyedGraph.
+//
+// NeighborIDs lists a neighbour once per edge and a node with a self-loop once
+// per loop. For directed graphs it lists the successors only.
+type GraphView[K comparable] interface {
+	NodeIDs() []K
+	HasNodeID(id K) bool
+	NeighborIDs(id K) []K
+	NumberOfNodes() int
+	NumberOfEdges() int
+	IsDirected() bool
+}
+
+// MutableGraph is a GraphView that nodes and edges can be added to and removed from.
+type MutableGraph[K comparable] interface {
+	GraphView[K]
+	AddNodeID(id K)
+	AddEdgeBetween(u, v K)
+	RemoveNodeID(id K)
+	RemoveEdgeBetween(u, v K)
+}
+
+// AttributedGraph is a MutableGraph whose nodes and edges carry attributes.
+// The returned maps belong to the graph, so changes to them are kept. They
+// are nil for nodes and edges that do not exist.
+type AttributedGraph[K comparable] interface {
+	MutableGraph[K]
+	NodeAttributes(id K) map[string]interface{}
+	EdgeAttributes(u, v K) map[string]interface{}
+}
+
+var (
+	_ MutableGraph[Node]      = (*UndirectedGraph)(nil)
+	_ MutableGraph[Node]      = (*WeightedUndirectedGraph)(nil)
+	_ MutableGraph[Node]      = (*DirectedGraph)(nil)
+	_ MutableGraph[string]    = (*KeyedGraph[string])(nil)
+	_ GraphView[Node]         = (*CSRGraph)(nil)
+	_ AttributedGraph[string] = (*NewGraph)(nil)
+)
+
+// UndirectedGraph
+
+func (g *UndirectedGraph) NodeIDs() []Node { return GetDictKeys(g.Nodes) }
+
+func (g *UndirectedGraph) HasNodeID(id Node) bool { return g.Nodes[id] }
+
+func (g *UndirectedGraph) NeighborIDs(id Node) []Node { return halveSelfLoops(g.Edges[id], id) }
+
+func (g *UndirectedGraph) NumberOfNodes() int { return len(g.Nodes) }
+
+func (g *UndirectedGraph) IsDirected() bool { return false }
+
+func (g *UndirectedGraph) AddNodeID(id Node) { g.AddNode(id) }
+
+func (g *UndirectedGraph) AddEdgeBetween(u, v Node) { g.AddEdge(Edge{Node1: u, Node2: v}) }
+
+func (g *UndirectedGraph) RemoveNodeID(id Node) { g.RemoveNode(id) }
+
+func (g *UndirectedGraph) RemoveEdgeBetween(u, v Node) { g.RemoveEdge(Edge{Node1: u, Node2: v}) }
+
+// WeightedUndirectedGraph overrides the mutating methods, so that weights are
+// kept in sync.
+
+func (g *WeightedUndirectedGraph) AddEdgeBetween(u, v Node) { g.AddEdge(Edge{Node1: u, Node2: v}) }
+
+func (g *WeightedUndirectedGraph) RemoveNodeID(id Node) { g.RemoveNode(id) }
+
+func (g *WeightedUndirectedGraph) RemoveEdgeBetween(u, v Node) {
+	g.RemoveEdge(Edge{Node1: u, Node2: v})
+}
+
+// DirectedGraph
+
+func (g *DirectedGraph) NodeIDs() []Node { return GetDictKeys(g.Nodes) }
+
+func (g *DirectedGraph) HasNodeID(id Node) bool { return g.Nodes[id] }
+
+func (g *DirectedGraph) NeighborIDs(id Node) []Node { return g.Out[id] }
+
+func (g *DirectedGraph) NumberOfNodes() int { return len(g.Nodes) }
+
+func (g *DirectedGraph) IsDirected() bool { return true }
+
+func (g *DirectedGraph) AddNodeID(id Node) { g.AddNode(id) }
+
+func (g *DirectedGraph) AddEdgeBetween(u, v Node) { g.AddEdge(Edge{Node1: u, Node2: v}) }
+
+func (g *DirectedGraph) RemoveNodeID(id Node) { g.RemoveNode(id) }
+
+func (g *DirectedGraph) RemoveEdgeBetween(u, v Node) { g.RemoveEdge(Edge{Node1: u, Node2: v}) }
+
+// KeyedGraph
+
+func (g *KeyedGraph[K]) NodeIDs() []K {
+	ids := make([]K, 0, len(g.Nodes))
+	for id := range g.Nodes {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (g *KeyedGraph[K]) HasNodeID(id K) bool { return g.Nodes[id] }
+
+func (g *KeyedGraph[K]) NeighborIDs(id K) []K { return halveSelfLoops(g.Edges[id], id) }
+
+func (g *KeyedGraph[K]) IsDirected() bool { return false }
+
+func (g *KeyedGraph[K]) AddNodeID(id K) { g.AddNode(id) }
+
+func (g *KeyedGraph[K]) AddEdgeBetween(u, v K) { g.AddEdge(KeyedEdge[K]{Node1: u, Node2: v}) }
+
+func (g *KeyedGraph[K]) RemoveNodeID(id K) { g.RemoveNode(id) }
+
+func (g *KeyedGraph[K]) RemoveEdgeBetween(u, v K) { g.RemoveEdge(KeyedEdge[K]{Node1: u, Node2: v}) }
+
+// CSRGraph
+
+func (g *CSRGraph) NodeIDs() []Node { return append([]Node(nil), g.ids...) }
+
+func (g *CSRGraph) HasNodeID(id Node) bool { return g.HasNode(id) }
+
+func (g *CSRGraph) NeighborIDs(id Node) []Node {
+	i, ok := g.index[id]
+	if !ok {
+		return nil
+	}
+	row := g.Neighbors(i)
+	neighbors := make([]Node, len(row))
+	for k, j := range row {
+		neighbors[k] = g.ids[j]
+	}
+	return halveSelfLoops(neighbors, id)
+}
+
+func (g *CSRGraph) IsDirected() bool { return false }
+
+// NewGraph
+
+func (g *NewGraph) NodeIDs() []string {
+	ids := make([]string, 0, len(g.Nodes))
+	for id := range g.Nodes {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (g *NewGraph) HasNodeID(id string) bool {
+	_, ok := g.Nodes[id]
+	return ok
+}
+
+func (g *NewGraph) NeighborIDs(id string) []string {
+	neighbors := []string{}
+	for _, key := range g.incidentEdges(id) {
+		edge := g.Edges[key]
+		switch {
+		case edge.First_node.ID == id:
+			neighbors = append(neighbors, edge.Second_node.ID)
+		case !g.IsDirected():
+			neighbors = append(neighbors, edge.First_node.ID)
+		}
+	}
+	return neighbors
+}
+
+func (g *NewGraph) IsDirected() bool { return g.Type == "digraph" }
+
+func (g *NewGraph) AddNodeID(id string) {
+	if !g.HasNodeID(id) {
+		g.AddNode(NewNode{ID: id, Attributes: map[string]interface{}{}})
+	}
+}
+
+func (g *NewGraph) AddEdgeBetween(u, v string) {
+	g.AddNodeID(u)
+	g.AddNodeID(v)
+	g.AddEdge(NewEdge{First_node: g.Nodes[u], Second_node: g.Nodes[v], Attributes: map[string]interface{}{}})
+}
+
+func (g *NewGraph) RemoveNodeID(id string) {
+	if node, ok := g.Nodes[id]; ok {
+		g.RemoveNode(node)
+	}
+}
+
+// RemoveEdgeBetween removes all edges between u and v, or only those from u to
+// v in a directed graph.
+func (g *NewGraph) RemoveEdgeBetween(u, v string) {
+	for _, key := range g.incidentEdges(u) {
+		edge := g.Edges[key]
+		forward := edge.First_node.ID == u && edge.Second_node.ID == v
+		backward := edge.First_node.ID == v && edge.Second_node.ID == u
+		if forward || (backward && !g.IsDirected()) {
+			g.RemoveEdgeByKey(key)
+		}
+	}
+}
+
+func (g *NewGraph) NodeAttributes(id string) map[string]interface{} {
+	node, ok := g.Nodes[id]
+	if !ok {
+		return nil
+	}
+	if node.Attributes == nil {
+		node.Attributes = map[string]interface{}{}
+		g.Nodes[id] = node
+	}
+	return node.Attributes
+}
+
+// EdgeAttributes returns the attributes of the edge between u and v. In a
+// multigraph these are the attributes of the parallel edge with the lowest key.
+func (g *NewGraph) EdgeAttributes(u, v string) map[string]interface{} {
+	keys := []int{}
+	for _, key := range g.incidentEdges(u) {
+		edge := g.Edges[key]
+		if (edge.First_node.ID == u && edge.Second_node.ID == v) || (edge.First_node.ID == v && edge.Second_node.ID == u) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Ints(keys)
+	edge := g.Edges[keys[0]]
+	if edge.Attributes == nil {
+		edge.Attributes = map[string]interface{}{}
+		g.setEdge(keys[0], edge)
+	}
+	return edge.Attributes
+}
+
+// halveSelfLoops returns the adjacency list with every second occurrence of
+// node removed, as adjacency lists store a self-loop twice.
+func halveSelfLoops[K comparable](neighbors []K, node K) []K {
+	loops := 0
+	for _, neighbor := range neighbors {
+		if neighbor == node {
+			loops++
+		}
+	}
+	if loops == 0 {
+		return neighbors
+	}
+	halved := make([]K, 0, len(neighbors)-loops/2)
+	seen := 0
+	for _, neighbor := range neighbors {
+		if neighbor == node {
+			seen++
+			if seen%2 == 0 {
+				continue
+			}
+		}
+		halved = append(halved, neighbor)
+	}
+	return halved
+}
+
+// ComponentsOf returns the node sets of the connected components of any graph,
+// largest first. Directed graphs are treated as undirected, so their weakly
+// connected components are returned.
+func ComponentsOf[K comparable](g GraphView[K]) [][]K {
+	adjacency := undirectedAdjacency(g)
+	visited := make(map[K]bool, g.NumberOfNodes())
+	components := [][]K{}
+	for _, start := range g.NodeIDs() {
+		if visited[start] {
+			continue
+		}
+		visited[start] = true
+		component := []K{start}
+		for head := 0; head < len(component); head++ {
+			for _, neighbor := range adjacency(component[head]) {
+				if !visited[neighbor] {
+					visited[neighbor] = true
+					component = append(component, neighbor)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	sort.SliceStable(components, func(i, j int) bool { return len(components[i]) > len(components[j]) })
+	return components
+}
+
+// undirectedAdjacency returns the neighbours of a node in both directions. For
+// undirected graphs this is NeighborIDs itself.
+func undirectedAdjacency[K comparable](g GraphView[K]) func(K) []K {
+	if !g.IsDirected() {
+		return g.NeighborIDs
+	}
+	predecessors := make(map[K][]K)
+	for _, u := range g.NodeIDs() {
+		for _, v := range g.NeighborIDs(u) {
+			predecessors[v] = append(predecessors[v], u)
+		}
+	}
+	return func(id K) []K {
+		return append(append([]K(nil), g.NeighborIDs(id)...), predecessors[id]...)
+	}
+}
+
+// CopyGraph adds all nodes and edges of src to dst, translating the node keys
+// with key. It lets the generators, which build UndirectedGraphs, fill any
+// MutableGraph, e.g. an attributed NewGraph:
+//
+//	g := BasicGraph()
+//	CopyGraph[Node, string](&g, CompleteGraph(5), func(n Node) string { return fmt.Sprint(n) })
+func CopyGraph[K, L comparable](dst MutableGraph[L], src GraphView[K], key func(K) L) {
+	ids := src.NodeIDs()
+	done := make(map[K]bool, len(ids))
+	for _, u := range ids {
+		dst.AddNodeID(key(u))
+	}
+	for _, u := range ids {
+		for _, v := range src.NeighborIDs(u) {
+			// in undirected graphs, every edge is listed by both of its nodes
+			if src.IsDirected() || !done[v] {
+				dst.AddEdgeBetween(key(u), key(v))
+			}
+		}
+		done[u] = true
+	}
+}
+
+// sortedNodeIDs returns the nodes of a graph sorted by their printed form.
+// NodeIDs may come from a map, so this makes anything derived from the order,
+// such as a seeded sample, depend on the graph alone.
+func sortedNodeIDs[K comparable](g GraphView[K]) []K {
+	ids := g.NodeIDs()
+	labels := make(map[K]string, len(ids))
+	for _, id := range ids {
+		labels[id] = fmt.Sprint(id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return labels[ids[i]] < labels[ids[j]] })
+	return ids
+}
+
+// indexedUndirected numbers the nodes of a graph in sortedNodeIDs order and
+// returns it as an UndirectedGraph, together with the key of every Node.
+func indexedUndirected[K comparable](g GraphView[K]) (*UndirectedGraph, []K) {
+	ids := sortedNodeIDs(g)
+	index := make(map[K]Node, len(ids))
+	for i, id := range ids {
+		index[id] = Node(i)
+	}
+	ng := &UndirectedGraph{Nodes: make(map[Node]bool, len(ids)), Edges: make(map[Node][]Node, len(ids))}
+	for i := range ids {
+		ng.AddNode(Node(i))
+	}
+	CopyGraph[K, Node](ng, g, func(id K) Node { return index[id] })
+	return ng, ids
+}
+
+// SampleNodes runs a sampling strategy on any graph and returns the keys of the
+// nodes that the sample kept. Directed graphs are sampled as undirected ones.
+func SampleNodes[K comparable](g GraphView[K], sampler ISamplingStrategy, sampledGraphSizeRatio float32) ([]K, error) {
+	ng, ids := indexedUndirected(g)
+	sample, err := sampler.Sample(ng, sampledGraphSizeRatio)
+	if err != nil {
+		return nil, err
+	}
+	kept := make([]K, 0, len(sample.Nodes))
+	for node := range sample.Nodes {
+		if int(node) < 0 || int(node) >= len(ids) {
+			return nil, fmt.Errorf("sampler returned unknown node %d", node)
+		}
+		kept = append(kept, ids[node])
+	}
+	return kept, nil
+}
+
+// RandomNodeSample returns a uniformly random subset of the nodes of any graph.
+// The ratio is clamped to [0, 1].
+func RandomNodeSample[K comparable](g GraphView[K], sampledGraphSizeRatio float32, seed int64) []K {
+	ids := sortedNodeIDs(g)
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	size := int(float32(len(ids)) * sampledGraphSizeRatio)
+	return ids[:max(0, min(size, len(ids)))]
+}
+
+// Sample runs a sampling strategy on the structure of the graph and returns
+// the subgraph induced by the sampled nodes. Nodes and edges keep their
+// attributes, and edges keep their IDs.
+func (g *NewGraph) Sample(sampler ISamplingStrategy, sampledGraphSizeRatio float32) (NewGraph, error) {
+	kept, err := SampleNodes[string](g, sampler, sampledGraphSizeRatio)
+	if err != nil {
+		return NewGraph{}, err
+	}
+	return g.inducedSubgraph(kept), nil
+}
+
+func (g *NewGraph) inducedSubgraph(ids []string) NewGraph {
+	sub := NewGraph{
+		Nodes:      make(map[string]NewNode, len(ids)),
+		Edges:      map[int]NewEdge{},
+		Type:       g.Type,
+		NodeSchema: g.NodeSchema,
+		EdgeSchema: g.EdgeSchema,
+	}
+	for _, id := range ids {
+		if node, ok := g.Nodes[id]; ok {
+			sub.Nodes[id] = node
+		}
+	}
+	for key, edge := range g.Edges {
+		_, first := sub.Nodes[edge.First_node.ID]
+		_, second := sub.Nodes[edge.Second_node.ID]
+		if first && second {
+			sub.setEdge(key, edge)
+		}
+	}
+	return sub
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+// evenNodeSampler keeps the nodes with even IDs.
+type evenNodeSampler struct{}
+
+func (evenNodeSampler) Sample(g *UndirectedGraph, _ float32) (*UndirectedGraph, error) {
+	sample := &UndirectedGraph{}
+	for node := range g.Nodes {
+		if node%2 == 0 {
+			sample.AddNode(node)
+		}
+	}
+	return sample, nil
+}
+
+func TestCopyGraph(t *testing.T) {
+	g := BasicGraph()
+	CopyGraph[Node, string](&g, CompleteGraph(5), func(n Node) string { return fmt.Sprint(n) })
+	if g.NumberOfNodes() != 5 || g.NumberOfEdges() != 10 {
+		t.Errorf("Expected 5 nodes and 10 edges, but got %d and %d", g.NumberOfNodes(), g.NumberOfEdges())
+	}
+
+	// Test case 2: Self-loops and directed edges are copied once
+	d := &DirectedGraph{}
+	d.AddEdge(Edge{Node1: 1, Node2: 2})
+	d.AddEdge(Edge{Node1: 2, Node2: 1})
+	d.AddEdge(Edge{Node1: 3, Node2: 3})
+	u := &UndirectedGraph{}
+	CopyGraph[Node, Node](u, d, func(n Node) Node { return n })
+	back := &DirectedGraph{}
+	CopyGraph[Node, Node](back, u, func(n Node) Node { return n })
+	if u.NumberOfEdges() != 3 || back.NumberOfEdges() != 3 || len(u.NeighborIDs(3)) != 1 {
+		t.Errorf("Expected 3 edges in both copies, but got %d and %d", u.NumberOfEdges(), back.NumberOfEdges())
+	}
+}
+
+func TestComponentsOf(t *testing.T) {
+	g := starNewGraph()
+	g.AddEdgeBetween("x", "y")
+	components := ComponentsOf[string](&g)
+	if len(components) != 2 || len(components[0]) != 4 || len(components[1]) != 2 {
+		t.Errorf("Expected components of sizes 4 and 2, but got %v", components)
+	}
+
+	// Test case 2: Directed graphs give their weakly connected components
+	directed := citationGraph()
+	directed.AddEdge(Edge{Node1: 4, Node2: 3})
+	if components := ComponentsOf[Node](directed); len(components) != 1 {
+		t.Errorf("Expected 1 component, but got %v", components)
+	}
+}
+
+func TestNewGraph_Sample(t *testing.T) {
+	g := BasicGraph()
+	CopyGraph[Node, string](&g, PathGraph(6), func(n Node) string { return fmt.Sprint(n) })
+	g.NodeAttributes("2")["title"] = "kept"
+	g.EdgeAttributes("2", "3")["weight"] = 1.5
+	// a chord between two sampled nodes is kept by the induced subgraph
+	g.AddEdgeBetween("2", "4")
+	g.EdgeAttributes("2", "4")["weight"] = 2.5
+	_, chord := g.GetEdgeByNodes(g.Nodes["2"], g.Nodes["4"])
+
+	// the sampler sees the nodes numbered in the same order every time
+	for run := 0; run < 20; run++ {
+		sample, err := g.Sample(evenNodeSampler{}, 0.5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		ids := sample.NodeIDs()
+		sort.Strings(ids)
+		if fmt.Sprint(ids) != "[0 2 4]" || sample.NumberOfEdges() != 1 {
+			t.Fatalf("Expected nodes [0 2 4] with 1 edge, but got %v and %d edges", ids, sample.NumberOfEdges())
+		}
+		if sample.NodeAttributes("2")["title"] != "kept" {
+			t.Errorf("Expected attributes to be kept, got %v", sample.Nodes["2"])
+		}
+		if sample.EdgeAttributes("2", "4")["weight"] != 2.5 {
+			t.Errorf("Expected edge attributes to be kept, got %v", sample.EdgeAttributes("2", "4"))
+		}
+		if edge, ok := sample.EdgeByID(chord); !ok || edge.Attributes["weight"] != 2.5 {
+			t.Errorf("Expected the chord to keep ID %d, got %v", chord, sample.Edges)
+		}
+	}
+
+	if got := RandomNodeSample[string](&g, 0.5, 1); len(got) != 3 {
+		t.Errorf("Expected 3 sampled nodes, but got %v", got)
+	}
+	// ratios outside [0, 1] are clamped
+	if got := RandomNodeSample[string](&g, 1.5, 1); len(got) != 6 {
+		t.Errorf("Expected 6 sampled nodes, but got %v", got)
+	}
+	if got := RandomNodeSample[string](&g, -0.5, 1); len(got) != 0 {
+		t.Errorf("Expected no sampled nodes, but got %v", got)
+	}
+}
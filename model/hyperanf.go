@@ -0,0 +1,236 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"runtime"
+	"sync"
+)
+
+// HyperANFOptions configures HyperANF.
+type HyperANFOptions struct {
+	// Log2Registers is the base-2 logarithm of the number of HyperLogLog
+	// registers per node, between 4 and 16, or 0 for the default of 7. Every
+	// node uses one byte per register, and the relative standard error of the
+	// estimates is about 1.04 / sqrt(2^Log2Registers).
+	Log2Registers int
+	// MaxDistance stops the computation after that many steps, or 0 to run
+	// until no counter changes.
+	MaxDistance int
+	// Workers is the number of goroutines, or 0 to use one per CPU.
+	Workers int
+	// Seed seeds the hash function of the counters.
+	Seed int64
+}
+
+// NeighborhoodFunction is an estimate of the neighbourhood function of a
+// graph: Values[t] is the number of ordered pairs of nodes (x, y), including
+// x = y, with a path of length at most t from x to y.
+type NeighborhoodFunction struct {
+	Values []float64
+}
+
+/*
+HyperANF estimates the neighbourhood function of the graph with HyperLogLog counters, from which the
+distance distribution, the effective diameter and the average distance follow. It takes
+O(m·2^b·d) time and n·2^b bytes of memory for n nodes, m edges, 2^b registers and diameter d, so it
+scales to graphs on which exact all-pairs shortest paths are infeasible.
+
+Parameters:
+- options: The number of registers, the maximal distance, the parallelism and the hash seed.
+
+Returns:
+- *NeighborhoodFunction: The estimated neighbourhood function.
+- error: An error if the number of registers is out of range.
+
+References:
+  - Boldi, P., Rosa, M., & Vigna, S. (2011). HyperANF: Approximating the neighbourhood function of very large graphs on a budget. Proceedings of the 20th international conference on World Wide Web, 625-634.
+  - Flajolet, P., Fusy, É., Gandouet, O., & Meunier, F. (2007). HyperLogLog: the analysis of a near-optimal cardinality estimation algorithm. Discrete Mathematics and Theoretical Computer Science, 137-156.
+*/
+func (g *CSRGraph) HyperANF(options HyperANFOptions) (*NeighborhoodFunction, error) {
+	log2m := options.Log2Registers
+	if log2m == 0 {
+		log2m = 7
+	}
+	if log2m < 4 || log2m > 16 {
+		return nil, fmt.Errorf("Log2Registers must be between 4 and 16, got %d", options.Log2Registers)
+	}
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	n := g.NumberOfNodes()
+	m := 1 << log2m
+	current := make([]uint8, n*m)
+	for i := 0; i < n; i++ {
+		hash := splitMix64(uint64(i) ^ uint64(options.Seed)*0x9e3779b97f4a7c15)
+		register := hash >> (64 - log2m)
+		// the rank is the position of the first 1-bit after the register bits
+		rank := bits.LeadingZeros64(hash<<log2m|1<<(log2m-1)) + 1
+		current[i*m+int(register)] = uint8(rank)
+	}
+	next := make([]uint8, n*m)
+
+	nf := &NeighborhoodFunction{Values: []float64{sumOfEstimates(current, n, m, workers)}}
+	for t := 1; options.MaxDistance == 0 || t <= options.MaxDistance; t++ {
+		changed := parallelRanges(n, workers, func(start, end int) bool {
+			changed := false
+			for x := start; x < end; x++ {
+				counter := next[x*m : (x+1)*m]
+				copy(counter, current[x*m:(x+1)*m])
+				for _, y := range g.Neighbors(int32(x)) {
+					for j, register := range current[int(y)*m : (int(y)+1)*m] {
+						if register > counter[j] {
+							counter[j] = register
+							changed = true
+						}
+					}
+				}
+			}
+			return changed
+		})
+		if !changed {
+			break
+		}
+		current, next = next, current
+		nf.Values = append(nf.Values, sumOfEstimates(current, n, m, workers))
+	}
+	return nf, nil
+}
+
+// HyperANF estimates the neighbourhood function of an UndirectedGraph by
+// converting it to a CSRGraph, see CSRGraph.HyperANF.
+func HyperANF(g *UndirectedGraph, options HyperANFOptions) (*NeighborhoodFunction, error) {
+	return NewCSRGraph(g).HyperANF(options)
+}
+
+// parallelRanges splits [0, n) into one contiguous range per worker, runs
+// process on all ranges concurrently and reports whether any call returned true.
+func parallelRanges(n, workers int, process func(start, end int) bool) bool {
+	size := (n + workers - 1) / max(workers, 1)
+	results := make([]bool, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start, end := w*size, min((w+1)*size, n)
+		if start >= end {
+			break
+		}
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			results[w] = process(start, end)
+		}(w)
+	}
+	wg.Wait()
+	for _, result := range results {
+		if result {
+			return true
+		}
+	}
+	return false
+}
+
+// sumOfEstimates returns the sum of the cardinality estimates of all counters.
+func sumOfEstimates(registers []uint8, n, m, workers int) float64 {
+	partial := make([]float64, workers)
+	size := (n + workers - 1) / max(workers, 1)
+	parallelRanges(n, workers, func(start, end int) bool {
+		for x := start; x < end; x++ {
+			partial[start/size] += hyperLogLogEstimate(registers[x*m : (x+1)*m])
+		}
+		return false
+	})
+	total := 0.0
+	for _, sum := range partial {
+		total += sum
+	}
+	return total
+}
+
+// hyperLogLogEstimate returns the estimated cardinality of a HyperLogLog
+// counter, using linear counting for small cardinalities.
+func hyperLogLogEstimate(registers []uint8) float64 {
+	m := float64(len(registers))
+	sum, zeros := 0.0, 0
+	for _, register := range registers {
+		sum += math.Ldexp(1, -int(register))
+		if register == 0 {
+			zeros++
+		}
+	}
+	var alpha float64
+	switch len(registers) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/m)
+	}
+	estimate := alpha * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return estimate
+}
+
+// splitMix64 is the finalizer of the SplitMix64 generator, a fast hash with
+// good avalanche behaviour.
+func splitMix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// DistanceDistribution returns the estimated fraction of the pairs of distinct
+// nodes connected by a path that are at each distance: entry t-1 is the
+// fraction at distance t.
+func (nf *NeighborhoodFunction) DistanceDistribution() []float64 {
+	if len(nf.Values) < 2 {
+		return []float64{}
+	}
+	reachable := nf.Values[len(nf.Values)-1] - nf.Values[0]
+	distribution := make([]float64, len(nf.Values)-1)
+	for t := 1; t < len(nf.Values); t++ {
+		if reachable > 0 {
+			distribution[t-1] = max(nf.Values[t]-nf.Values[t-1], 0) / reachable
+		}
+	}
+	return distribution
+}
+
+// AverageDistance returns the estimated mean distance between pairs of
+// distinct nodes connected by a path.
+func (nf *NeighborhoodFunction) AverageDistance() float64 {
+	average := 0.0
+	for i, fraction := range nf.DistanceDistribution() {
+		average += float64(i+1) * fraction
+	}
+	return average
+}
+
+// EffectiveDiameter returns the estimated distance within which the given
+// fraction, e.g. 0.9, of the pairs of nodes connected by a path lie, counting
+// every node as connected to itself as the neighbourhood function does. It is
+// interpolated linearly between integer distances.
+func (nf *NeighborhoodFunction) EffectiveDiameter(fraction float64) float64 {
+	if len(nf.Values) == 0 {
+		return 0
+	}
+	threshold := fraction * nf.Values[len(nf.Values)-1]
+	for t := 1; t < len(nf.Values); t++ {
+		if nf.Values[t] >= threshold {
+			step := nf.Values[t] - nf.Values[t-1]
+			if step <= 0 {
+				return float64(t)
+			}
+			return float64(t-1) + max(threshold-nf.Values[t-1], 0)/step
+		}
+	}
+	return float64(len(nf.Values) - 1)
+}
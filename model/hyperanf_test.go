@@ -0,0 +1,48 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHyperANF(t *testing.T) {
+	// on a path of n nodes there are 2(n-t) ordered pairs at distance t, and the average distance is (n+1)/3
+	n := 20
+	nf, err := HyperANF(PathGraph(n), HyperANFOptions{Log2Registers: 10, Workers: 3, Seed: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(nf.Values) != n {
+		t.Errorf("Expected %d values, but got %d", n, len(nf.Values))
+	}
+	for dist, value := range nf.Values {
+		exact := float64(n)
+		for s := 1; s <= dist; s++ {
+			exact += float64(2 * (n - s))
+		}
+		if math.Abs(value-exact) > 0.1*exact {
+			t.Errorf("Expected about %v pairs within distance %d, but got %v", exact, dist, value)
+		}
+	}
+	if average := nf.AverageDistance(); math.Abs(average-7) > 0.7 {
+		t.Errorf("Expected an average distance of about 7, but got %v", average)
+	}
+	distribution := nf.DistanceDistribution()
+	if len(distribution) != n-1 || distribution[0] < distribution[n-2] {
+		t.Errorf("Expected a decreasing distance distribution, but got %v", distribution)
+	}
+
+	// Test case 2: MaxDistance stops early, and the effective diameter of a complete graph is below 1
+	nf, _ = NewCSRGraph(CompleteGraph(30)).HyperANF(HyperANFOptions{MaxDistance: 5})
+	if len(nf.Values) != 2 || nf.EffectiveDiameter(0.9) >= 1 {
+		t.Errorf("Expected 2 values and an effective diameter below 1, but got %v and %v", len(nf.Values), nf.EffectiveDiameter(0.9))
+	}
+	nf, _ = HyperANF(PathGraph(n), HyperANFOptions{MaxDistance: 3})
+	if len(nf.Values) != 4 {
+		t.Errorf("Expected 4 values, but got %d", len(nf.Values))
+	}
+
+	if _, err := HyperANF(PathGraph(n), HyperANFOptions{Log2Registers: 20}); err == nil {
+		t.Errorf("Expected an error for too many registers")
+	}
+}
@@ -0,0 +1,353 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// KeyedEdge is an undirected edge of a KeyedGraph.
+type KeyedEdge[K comparable] struct {
+	Node1 K
+	Node2 K
+}
+
+// KeyedGraph is an undirected graph over arbitrary comparable node keys, such
+// as strings or URLs. It has the same layout and operations as UndirectedGraph,
+// which is the KeyedGraph of Node in all but name. Like the zero value of
+// UndirectedGraph, it is always a multigraph: AddEdge, ContractNode and
+// ContractEdge keep parallel edges and self-loops.
+type KeyedGraph[K comparable] struct {
+	Nodes map[K]bool
+	Edges map[K][]K
+}
+
+func (g *KeyedGraph[K]) String() string {
+	var str strings.Builder
+
+	str.WriteString("Nodes:\n")
+	for node := range g.Nodes {
+		str.WriteString(fmt.Sprintf("%v: true\t", node))
+	}
+
+	str.WriteString("\nEdges:\n")
+	for node, edges := range g.Edges {
+		str.WriteString(fmt.Sprintf("%v: %v\n", node, edges))
+	}
+
+	return str.String()
+}
+
+// Equals reports whether both graphs have the same nodes and adjacency lists,
+// regardless of the order of the neighbours.
+func (g *KeyedGraph[K]) Equals(other *KeyedGraph[K]) bool {
+	if len(g.Nodes) != len(other.Nodes) {
+		return false
+	}
+	for node := range g.Nodes {
+		if !other.Nodes[node] {
+			return false
+		}
+	}
+	for node, edges := range g.Edges {
+		otherEdges, ok := other.Edges[node]
+		if !ok || len(edges) != len(otherEdges) {
+			return false
+		}
+		for _, edge := range edges {
+			if !containsKey(otherEdges, edge) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// AddNode adds a node to the graph.
+func (g *KeyedGraph[K]) AddNode(node K) {
+	if g.Nodes == nil {
+		g.Nodes = make(map[K]bool)
+	}
+	g.Nodes[node] = true
+}
+
+// AddNodes adds every node of the slice to the graph.
+func (g *KeyedGraph[K]) AddNodes(nodes []K) {
+	for _, node := range nodes {
+		g.AddNode(node)
+	}
+}
+
+// AddEdge adds an undirected edge and both of its nodes to the graph.
+func (g *KeyedGraph[K]) AddEdge(edge KeyedEdge[K]) {
+	if g.Edges == nil {
+		g.Edges = make(map[K][]K)
+	}
+	g.AddNode(edge.Node1)
+	g.AddNode(edge.Node2)
+	g.Edges[edge.Node1] = append(g.Edges[edge.Node1], edge.Node2)
+	g.Edges[edge.Node2] = append(g.Edges[edge.Node2], edge.Node1)
+}
+
+// HasNode reports whether the graph contains the node.
+func (g *KeyedGraph[K]) HasNode(node K) bool {
+	return g.Nodes[node]
+}
+
+// NodeDegree returns the number of edges incident to the node.
+func (g *KeyedGraph[K]) NodeDegree(node K) int {
+	if !g.Nodes[node] {
+		return 0
+	}
+	return len(g.Edges[node])
+}
+
+// NumberOfNodes returns the number of nodes in the graph.
+func (g *KeyedGraph[K]) NumberOfNodes() int {
+	return len(g.Nodes)
+}
+
+// NumberOfEdges returns the number of undirected edges in the graph.
+func (g *KeyedGraph[K]) NumberOfEdges() int {
+	total := 0
+	for _, neighbors := range g.Edges {
+		total += len(neighbors)
+	}
+	return total / 2
+}
+
+// GetEdgeTuples returns every edge of the graph once in each direction, like
+// UndirectedGraph.GetEdgeTuples.
+func (g *KeyedGraph[K]) GetEdgeTuples() []KeyedEdge[K] {
+	var edges []KeyedEdge[K]
+	for node1, neighbors := range g.Edges {
+		for _, node2 := range neighbors {
+			edges = append(edges, KeyedEdge[K]{node1, node2})
+		}
+	}
+	return edges
+}
+
+// RemoveEdge disconnects edge.Node1 and edge.Node2.
+func (g *KeyedGraph[K]) RemoveEdge(edge KeyedEdge[K]) {
+	if len(g.Edges[edge.Node1]) > 0 {
+		g.Edges[edge.Node1] = deleteKey(g.Edges[edge.Node1], edge.Node2)
+	}
+	if len(g.Edges[edge.Node2]) > 0 {
+		g.Edges[edge.Node2] = deleteKey(g.Edges[edge.Node2], edge.Node1)
+	}
+}
+
+// RemoveNode removes the node and all of its edges.
+func (g *KeyedGraph[K]) RemoveNode(node K) {
+	delete(g.Nodes, node)
+	for _, neighbor := range g.Edges[node] {
+		if neighbor != node {
+			g.Edges[neighbor] = deleteKey(g.Edges[neighbor], node)
+		}
+	}
+	delete(g.Edges, node)
+}
+
+// ContractNode removes the node and connects each pair of its neighbours, even
+// if they are already connected.
+func (g *KeyedGraph[K]) ContractNode(node K) {
+	neighbors := append([]K(nil), g.Edges[node]...)
+	for i := 0; i < len(neighbors); i++ {
+		for j := i + 1; j < len(neighbors); j++ {
+			if neighbors[i] != node && neighbors[j] != node {
+				g.AddEdge(KeyedEdge[K]{Node1: neighbors[i], Node2: neighbors[j]})
+			}
+		}
+	}
+	g.RemoveNode(node)
+}
+
+// ContractEdge merges edge.Node1 into edge.Node2: the neighbours of Node1
+// become neighbours of Node2 and Node1 is removed.
+func (g *KeyedGraph[K]) ContractEdge(edge KeyedEdge[K]) {
+	for _, neighbor := range g.Edges[edge.Node1] {
+		g.AddEdge(KeyedEdge[K]{Node1: neighbor, Node2: edge.Node2})
+	}
+	g.RemoveNode(edge.Node1)
+}
+
+// DFS returns the spanning tree of the depth-first search from startNode, or an
+// empty graph if the graph does not contain it.
+func (g *KeyedGraph[K]) DFS(startNode K) *KeyedGraph[K] {
+	visitedGraph := &KeyedGraph[K]{Nodes: make(map[K]bool), Edges: make(map[K][]K)}
+	if !g.Nodes[startNode] {
+		return visitedGraph
+	}
+	visitedGraph.AddNode(startNode)
+	// an explicit stack instead of recursion, so long paths do not grow the call stack
+	type frame struct {
+		node K
+		next int
+	}
+	stack := []frame{{node: startNode}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		neighbors := g.Edges[top.node]
+		if top.next == len(neighbors) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		neighbor := neighbors[top.next]
+		top.next++
+		if !visitedGraph.Nodes[neighbor] {
+			visitedGraph.AddEdge(KeyedEdge[K]{Node1: top.node, Node2: neighbor})
+			stack = append(stack, frame{node: neighbor})
+		}
+	}
+	return visitedGraph
+}
+
+// KeyedConnectedComponents returns the connected components of the graph,
+// largest first.
+func KeyedConnectedComponents[K comparable](g *KeyedGraph[K]) []*KeyedGraph[K] {
+	visited := make(map[K]bool, len(g.Nodes))
+	components := []*KeyedGraph[K]{}
+	for node := range g.Nodes {
+		if visited[node] {
+			continue
+		}
+		component := g.DFS(node)
+		for member := range component.Nodes {
+			visited[member] = true
+		}
+		components = append(components, component)
+	}
+	sort.SliceStable(components, func(i, j int) bool {
+		return len(components[i].Nodes) > len(components[j].Nodes)
+	})
+	return components
+}
+
+// KeyedFromUndirected copies an UndirectedGraph into a KeyedGraph of Node.
+func KeyedFromUndirected(g *UndirectedGraph) *KeyedGraph[Node] {
+	keyed := &KeyedGraph[Node]{
+		Nodes: make(map[Node]bool, len(g.Nodes)),
+		Edges: make(map[Node][]Node, len(g.Edges)),
+	}
+	for node := range g.Nodes {
+		keyed.Nodes[node] = true
+	}
+	for node, neighbors := range g.Edges {
+		keyed.Edges[node] = append([]Node(nil), neighbors...)
+	}
+	return keyed
+}
+
+// ToUndirected numbers the nodes of the graph and returns the resulting
+// UndirectedGraph, together with the key of every Node. The nodes are numbered
+// from 0 in the order of their fmt.Sprint representation, so the numbering is
+// the same every time.
+func (g *KeyedGraph[K]) ToUndirected() (*UndirectedGraph, map[Node]K) {
+	keys := sortedKeys(g.Nodes)
+	ids := make(map[K]Node, len(keys))
+	mapping := make(map[Node]K, len(keys))
+	for i, key := range keys {
+		ids[key] = Node(i)
+		mapping[Node(i)] = key
+	}
+
+	ng := &UndirectedGraph{
+		Nodes: make(map[Node]bool, len(keys)),
+		Edges: make(map[Node][]Node, len(g.Edges)),
+	}
+	for _, key := range keys {
+		ng.Nodes[ids[key]] = true
+		neighbors, ok := g.Edges[key]
+		if !ok {
+			continue
+		}
+		converted := make([]Node, 0, len(neighbors))
+		for _, neighbor := range neighbors {
+			if id, ok := ids[neighbor]; ok {
+				converted = append(converted, id)
+			}
+		}
+		ng.Edges[ids[key]] = converted
+	}
+	return ng, mapping
+}
+
+// KeyedFromNewGraph copies the structure of a NewGraph into a KeyedGraph keyed
+// by node ID. Attributes are dropped.
+func KeyedFromNewGraph(g *NewGraph) *KeyedGraph[string] {
+	keyed := &KeyedGraph[string]{
+		Nodes: make(map[string]bool, len(g.Nodes)),
+		Edges: make(map[string][]string),
+	}
+	for id := range g.Nodes {
+		keyed.AddNode(id)
+	}
+	for _, edge := range g.Edges {
+		keyed.AddEdge(KeyedEdge[string]{Node1: edge.First_node.ID, Node2: edge.Second_node.ID})
+	}
+	return keyed
+}
+
+// ToNewGraph converts the graph to a NewGraph of type "multigraph", with node
+// IDs given by fmt.Sprint of the keys and no attributes. Parallel edges and
+// self-loops are kept.
+func (g *KeyedGraph[K]) ToNewGraph() NewGraph {
+	ng := MultiGraph()
+	keys := sortedKeys(g.Nodes)
+	nodes := make(map[K]NewNode, len(keys))
+	for _, key := range keys {
+		nodes[key] = NewNode{ID: fmt.Sprint(key), Attributes: map[string]interface{}{}}
+		ng.AddNode(nodes[key])
+	}
+	// every edge appears in the lists of both of its nodes, and a self-loop
+	// twice in the list of its node, so the second entry of an edge is skipped
+	pending := make(map[KeyedEdge[K]]int)
+	for _, key := range keys {
+		for _, neighbor := range g.Edges[key] {
+			other, ok := nodes[neighbor]
+			if !ok {
+				continue
+			}
+			if reverse := (KeyedEdge[K]{Node1: neighbor, Node2: key}); pending[reverse] > 0 {
+				pending[reverse]--
+				continue
+			}
+			pending[KeyedEdge[K]{Node1: key, Node2: neighbor}]++
+			ng.AddEdge(NewEdge{First_node: nodes[key], Second_node: other, Attributes: map[string]interface{}{}})
+		}
+	}
+	return ng
+}
+
+// sortedKeys returns the keys of the set in the order of their fmt.Sprint
+// representation, which gives a stable order for any comparable type.
+func sortedKeys[K comparable](set map[K]bool) []K {
+	keys := make([]K, 0, len(set))
+	labels := make(map[K]string, len(set))
+	for key := range set {
+		keys = append(keys, key)
+		labels[key] = fmt.Sprint(key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return labels[keys[i]] < labels[keys[j]] })
+	return keys
+}
+
+func containsKey[K comparable](keys []K, key K) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func deleteKey[K comparable](keys []K, key K) []K {
+	kept := []K{}
+	for _, k := range keys {
+		if k != key {
+			kept = append(kept, k)
+		}
+	}
+	return kept
+}
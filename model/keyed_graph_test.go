@@ -0,0 +1,81 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func citationURLs() *KeyedGraph[string] {
+	g := &KeyedGraph[string]{}
+	g.AddEdge(KeyedEdge[string]{Node1: "https://a.org", Node2: "https://b.org"})
+	g.AddEdge(KeyedEdge[string]{Node1: "https://b.org", Node2: "https://c.org"})
+	g.AddEdge(KeyedEdge[string]{Node1: "https://c.org", Node2: "https://a.org"})
+	g.AddEdge(KeyedEdge[string]{Node1: "https://x.org", Node2: "https://y.org"})
+	return g
+}
+
+func TestKeyedGraph_Operations(t *testing.T) {
+	g := citationURLs()
+
+	if g.NumberOfNodes() != 5 || g.NumberOfEdges() != 4 || g.NodeDegree("https://a.org") != 2 {
+		t.Errorf("Unexpected graph %v", g)
+	}
+	components := KeyedConnectedComponents(g)
+	if len(components) != 2 || components[0].NumberOfNodes() != 3 {
+		t.Errorf("Expected components of sizes 3 and 2, but got %v", components)
+	}
+
+	g.RemoveEdge(KeyedEdge[string]{Node1: "https://b.org", Node2: "https://a.org"})
+	g.RemoveNode("https://y.org")
+	expected := map[string][]string{
+		"https://a.org": {"https://c.org"},
+		"https://b.org": {"https://c.org"},
+		"https://c.org": {"https://b.org", "https://a.org"},
+		"https://x.org": {},
+	}
+	if !reflect.DeepEqual(g.Edges, expected) {
+		t.Errorf("Expected %v, but got %v", expected, g.Edges)
+	}
+
+	// Test case 2: Contracting c connects a and b
+	g.ContractNode("https://c.org")
+	if !containsKey(g.Edges["https://a.org"], "https://b.org") || g.HasNode("https://c.org") {
+		t.Errorf("Expected a and b to be connected, got %v", g.Edges)
+	}
+}
+
+func TestKeyedGraph_Conversions(t *testing.T) {
+	g := citationURLs()
+
+	undirected, mapping := g.ToUndirected()
+	if mapping[0] != "https://a.org" || mapping[4] != "https://y.org" {
+		t.Errorf("Expected nodes numbered in sorted order, but got %v", mapping)
+	}
+	if undirected.NumberOfEdges() != 4 || undirected.NodeDegree(1) != 2 {
+		t.Errorf("Unexpected converted graph %v", undirected)
+	}
+	if back := KeyedFromUndirected(undirected); back.NumberOfEdges() != 4 || !back.HasNode(4) {
+		t.Errorf("Unexpected round trip %v", back)
+	}
+
+	ng := g.ToNewGraph()
+	if ng.NumberOfNodes() != 5 || ng.NumberOfEdges() != 4 {
+		t.Errorf("Expected 5 nodes and 4 edges, but got %d and %d", ng.NumberOfNodes(), ng.NumberOfEdges())
+	}
+	if !KeyedFromNewGraph(&ng).Equals(g) {
+		t.Errorf("Expected the NewGraph round trip to give back the original graph")
+	}
+
+	// Test case 2: Parallel edges and self-loops survive the conversion
+	multi := &KeyedGraph[string]{}
+	multi.AddEdge(KeyedEdge[string]{Node1: "a", Node2: "b"})
+	multi.AddEdge(KeyedEdge[string]{Node1: "b", Node2: "a"})
+	multi.AddEdge(KeyedEdge[string]{Node1: "c", Node2: "c"})
+	ng = multi.ToNewGraph()
+	if ng.Type != "multigraph" || ng.NumberOfEdges() != 3 {
+		t.Errorf("Expected a multigraph with 3 edges, but got %q with %d", ng.Type, ng.NumberOfEdges())
+	}
+	if !KeyedFromNewGraph(&ng).Equals(multi) {
+		t.Errorf("Expected the NewGraph round trip to give back the multigraph, got %v", KeyedFromNewGraph(&ng))
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -12,6 +13,20 @@ type NewGraph struct {
 	Nodes map[string]NewNode
 	Edges map[int]NewEdge
 	Type  string
+
+	// NodeSchema and EdgeSchema declare the types of the node and edge
+	// attributes. Graphs without schemas accept attributes of any type.
+	NodeSchema AttributeSchema `json:",omitempty"`
+	EdgeSchema AttributeSchema `json:",omitempty"`
+
+	// incidence maps a node ID to the keys of its incident edges in Edges. It
+	// is built on first use and kept up to date by the methods of the graph.
+	incidence map[string]map[int]struct{}
+	// nextEdgeID is the key given to the next edge added to the graph. Keys
+	// are never reused, so removing an edge does not change the IDs of others.
+	// ToJSON writes it as NextEdgeID when the last keys were removed, so that
+	// a decoded graph does not reuse them either.
+	nextEdgeID int
 }
 
 type NewNode struct {
@@ -54,6 +69,27 @@ func (g *NewGraph) ToJSON() ([]byte, error) {
 	return json.Marshal(g)
 }
 
+// MarshalJSON encodes the fields of the graph, and the key of the next edge if
+// it cannot be derived from the keys in Edges.
+func (g NewGraph) MarshalJSON() ([]byte, error) {
+	// graphJSON has the fields of NewGraph but not its methods, which avoids
+	// calling MarshalJSON recursively
+	type graphJSON NewGraph
+	nextEdgeID := 0
+	for key := range g.Edges {
+		if key >= nextEdgeID {
+			nextEdgeID = key + 1
+		}
+	}
+	if g.nextEdgeID <= nextEdgeID {
+		return json.Marshal(graphJSON(g))
+	}
+	return json.Marshal(struct {
+		graphJSON
+		NextEdgeID int
+	}{graphJSON(g), g.nextEdgeID})
+}
+
 // WriteToFile writes the graph data to a JSON file
 func (g *NewGraph) WriteToFile(filename string) error {
 	data, err := g.ToJSON()
@@ -77,8 +113,24 @@ func (g NewGraph) IsEqual(other NewGraph) bool {
 		}
 	}
 
-	for i := 0; i < len(g.Edges); i++ {
-		if !g.CompareEdges(g.Edges[i], other.Edges[i]) {
+	// edges are matched regardless of their IDs, grouped by their endpoints
+	unmatched := make(map[string][]NewEdge, len(other.Edges))
+	for _, edge := range other.Edges {
+		key := endpointsKey(edge)
+		unmatched[key] = append(unmatched[key], edge)
+	}
+	for _, edge := range g.Edges {
+		key := endpointsKey(edge)
+		candidates := unmatched[key]
+		found := false
+		for i, candidate := range candidates {
+			if g.CompareEdges(edge, candidate) {
+				unmatched[key] = append(candidates[:i], candidates[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
 			return false
 		}
 	}
@@ -86,6 +138,14 @@ func (g NewGraph) IsEqual(other NewGraph) bool {
 	return true
 }
 
+// endpointsKey identifies the unordered pair of nodes an edge connects.
+func endpointsKey(e NewEdge) string {
+	if e.First_node.ID < e.Second_node.ID {
+		return e.First_node.ID + "\x00" + e.Second_node.ID
+	}
+	return e.Second_node.ID + "\x00" + e.First_node.ID
+}
+
 // Removes from graph all nodes that have only one neighbor
 func (g *NewGraph) RemoveLeaves() {
 	leaves := []NewNode{}
@@ -107,40 +167,48 @@ func (g *NewGraph) CombineLeaves() {
 	leaves := []NewNode{}
 
 	for _, node := range g.Nodes {
-		if len(g.Neighbors(node)) == 1 {
+		if g.NodeDegree(node) == 1 {
 			leaves = append(leaves, node)
 		}
 	}
 
-	N := make(map[string]NewNode)
 	for _, leave := range leaves {
-		x := g.Neighbors(leave)[0]
+		neighbors := g.Neighbors(leave)
+		// the leaf may already have been merged into another node
+		if len(neighbors) != 1 {
+			continue
+		}
+		x := neighbors[0]
+		if current, ok := g.Nodes[x.ID]; ok {
+			x = current
+		}
+		if current, ok := g.Nodes[leave.ID]; ok {
+			leave = current
+		}
 		n := g.CombineNodes(leave, x, StrategyArray{}, StrategyArray{})
-		g.Nodes[x.ID] = n
-		for _, node := range g.Neighbors(x) {
-			e, edge_idx := g.GetEdgeByNodes(x, node)
+
+		for _, key := range g.incidentEdges(x.ID) {
+			e := g.Edges[key]
 			if e.First_node.ID == leave.ID || e.Second_node.ID == leave.ID {
-				g.RemoveEdge(e)
-			} else {
-				if e.First_node.ID == x.ID {
-					e.First_node = n
-				} else {
-					e.Second_node = n
-				}
+				g.RemoveEdgeByKey(key)
+				continue
 			}
-			g.Edges[edge_idx] = e
-
+			if e.First_node.ID == x.ID {
+				e.First_node = n
+			}
+			if e.Second_node.ID == x.ID {
+				e.Second_node = n
+			}
+			g.setEdge(key, e)
 		}
-		*g = g.RemoveNode(leave)
-		*g = g.RemoveNode(x)
+		g.RemoveNode(leave)
+		g.RemoveNode(x)
+		g.Nodes[n.ID] = n
 	}
-	for _, value := range g.Nodes {
-		N[value.ID] = value
-	}
-	g.Nodes = N
 }
 
-// Combines two graphs into one
+// Combines two graphs into one. The edges of g keep their IDs, while the edges
+// of h that g does not already have are given new ones.
 func (g NewGraph) Combine(h NewGraph) NewGraph {
 	if g.Type != h.Type {
 		fmt.Println("Graphs must be the same type!")
@@ -148,9 +216,11 @@ func (g NewGraph) Combine(h NewGraph) NewGraph {
 	}
 
 	k := NewGraph{
-		Nodes: map[string]NewNode{},
-		Edges: map[int]NewEdge{},
-		Type:  g.Type,
+		Nodes:      map[string]NewNode{},
+		Edges:      map[int]NewEdge{},
+		Type:       g.Type,
+		NodeSchema: g.NodeSchema,
+		EdgeSchema: g.EdgeSchema,
 	}
 
 	for key, value := range g.Nodes {
@@ -160,10 +230,12 @@ func (g NewGraph) Combine(h NewGraph) NewGraph {
 		k.Nodes[key] = value
 	}
 	for key, value := range g.Edges {
-		k.Edges[key] = value
+		k.setEdge(key, value)
 	}
-	for key, value := range h.Edges {
-		k.Edges[key] = value
+	for _, value := range h.Edges {
+		if !k.HasEdge(value) {
+			k.setEdge(k.newEdgeID(), value)
+		}
 	}
 	return k
 }
@@ -197,6 +269,8 @@ func (g NewGraph) NewDFS(startNode NewNode, visited map[string]bool) NewGraph {
 	if !exists {
 		return NewGraph{}
 	}
+	// build the edge index once, so the copies made by NewDfsUtil share it
+	g.edgeIndex()
 	vis := make(map[string]bool)
 	visitedGraph := NewGraph{
 		Nodes: make(map[string]NewNode),
@@ -225,6 +299,7 @@ func (g NewGraph) NewDfsUtil(node NewNode, visited map[string]bool, visitedGraph
 
 // returns a slice of all components, separated (each graph in a slice only has one component)
 func (g NewGraph) GetComponents() []NewGraph {
+	g.edgeIndex()
 	visited := make(map[string]bool)
 	for key := range g.Nodes {
 		visited[key] = false
@@ -265,64 +340,61 @@ func CompareNodes(n1, n2 NewNode) bool {
 
 // Adds a node to a graph
 func (g *NewGraph) AddNode(node NewNode) {
-	for key := range g.Nodes {
-		if node.ID == key {
-			for key, value := range node.Attributes {
-				existingvalue, isfound := g.GetNode(node.ID).Attributes[key]
-				if isfound {
-					v, ok := existingvalue.(int)
-					u, okk := value.(int)
-					if ok && okk {
-						g.GetNode(node.ID).Attributes[key] = v + u
-					} else {
-						g.GetNode(node.ID).Attributes[key] = value
-					}
+	if existing, ok := g.Nodes[node.ID]; ok {
+		if existing.Attributes == nil {
+			existing.Attributes = map[string]interface{}{}
+			g.Nodes[node.ID] = existing
+		}
+		for key, value := range node.Attributes {
+			existingvalue, isfound := existing.Attributes[key]
+			if isfound {
+				v, ok := existingvalue.(int)
+				u, okk := value.(int)
+				if ok && okk {
+					existing.Attributes[key] = v + u
 				} else {
-					g.GetNode(node.ID).Attributes[key] = value
+					existing.Attributes[key] = value
 				}
+			} else {
+				existing.Attributes[key] = value
 			}
-			return
 		}
+		return
 	}
 	g.Nodes[node.ID] = node
 }
 
 // Adds nodes from a slice to a graph
-func (g NewGraph) AddNodesFrom(arr []NewNode) NewGraph {
+func (g *NewGraph) AddNodesFrom(arr []NewNode) NewGraph {
 	for _, node := range arr {
 		g.AddNode(node)
 	}
-	return g
+	return *g
 }
 
 // Checks if graph already has node n
 func (g NewGraph) HasNode(n NewNode) bool {
-	for _, node := range g.Nodes {
-		if CompareNodes(n, node) {
-			return true
-		}
-	}
-	return false
+	_, ok := g.Nodes[n.ID]
+	return ok
 }
 
 // Returns a node in graph with provided id
 func (g NewGraph) GetNode(id string) *NewNode {
-	for _, node := range g.Nodes {
-		if node.ID == id {
-			return &node
-		}
+	node, ok := g.Nodes[id]
+	if !ok {
+		return nil
 	}
-	return nil
+	return &node
 }
 
 // Adds an attribute to a node with corresponding id
-func (g NewGraph) AddNodeAttribute(node_id string, att string, value interface{}) NewGraph {
+func (g *NewGraph) AddNodeAttribute(node_id string, att string, value interface{}) NewGraph {
 	if g.GetNode(node_id) == nil {
 		fmt.Println("this graph does not have node with id:", node_id)
-		return g
+		return *g
 	}
 	g.GetNode((node_id)).Attributes[att] = value
-	return g
+	return *g
 }
 
 // Returns the number of nodes in graph
@@ -331,13 +403,14 @@ func (g NewGraph) NumberOfNodes() int {
 }
 
 // Returns a slice, which contains all of the neighbors of a provided node
-func (g NewGraph) Neighbors(n NewNode) []NewNode {
+func (g *NewGraph) Neighbors(n NewNode) []NewNode {
 	neighbors := []NewNode{}
 
-	for _, edge := range g.Edges {
+	for _, key := range g.incidentEdges(n.ID) {
+		edge := g.Edges[key]
 		if CompareNodes(edge.First_node, n) {
 			neighbors = append(neighbors, edge.Second_node)
-		} else if CompareNodes(edge.Second_node, n) {
+		} else {
 			neighbors = append(neighbors, edge.First_node)
 		}
 	}
@@ -347,33 +420,24 @@ func (g NewGraph) Neighbors(n NewNode) []NewNode {
 }
 
 // Removes node from a graph
-func (g NewGraph) RemoveNode(n NewNode) NewGraph {
-	for i, node := range g.Nodes {
-		if CompareNodes(node, n) {
-			delete(g.Nodes, i)
-			for j, edge := range g.Edges {
-				if edge.First_node.ID == i || edge.Second_node.ID == i {
-					delete(g.Edges, j)
-				}
-			}
-		}
+func (g *NewGraph) RemoveNode(n NewNode) NewGraph {
+	if _, ok := g.Nodes[n.ID]; !ok {
+		return *g
+	}
+	delete(g.Nodes, n.ID)
+	for _, key := range g.incidentEdges(n.ID) {
+		g.RemoveEdgeByKey(key)
 	}
-	return g
+	return *g
 }
 
 // Returns the number of neighbors this node has.
-func (g NewGraph) NodeDegree(n NewNode) int {
-	counter := 0
-	for _, edge := range g.Edges {
-		if CompareNodes(n, edge.First_node) || CompareNodes(n, edge.Second_node) {
-			counter++
-		}
-	}
-	return counter
+func (g *NewGraph) NodeDegree(n NewNode) int {
+	return len(g.edgeIndex()[n.ID])
 }
 
 // Deletes a node from a graph and connects all of the neighbours of deleted node
-func (g NewGraph) ContractNewNode(n NewNode) {
+func (g *NewGraph) ContractNewNode(n NewNode) {
 	if !g.HasNode(n) {
 		fmt.Println("This graph does not have this node.")
 		return
@@ -495,6 +559,11 @@ func (s StrategyAvgNum) CombineFloat64(v1, v2 interface{}) interface{} {
 	return (n1 + n2) / 2
 }
 
+// Strings cannot be averaged, so StrategyAvgNum keeps the first value.
+func (s StrategyAvgNum) CombineString(v1, v2 interface{}) interface{} {
+	return v1
+}
+
 func (s StrategyArray) CombineInt(v1, v2 interface{}) interface{} {
 	if !(reflect.TypeOf(v1).Kind() == reflect.Slice) {
 		nv := v1.(int)
@@ -567,6 +636,48 @@ func (s StrategyArray) CombineString(n1, n2 interface{}) interface{} {
 	return append(n1.([]string), n2.([]string)...)
 }
 
+func copyAttributes(attributes map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(attributes))
+	for key, value := range attributes {
+		copied[key] = value
+	}
+	return copied
+}
+
+// combineAttributes merges two attribute maps into a new one. Values present in
+// both are combined with strat_num for numbers and strat_string for strings,
+// including values already combined into slices by StrategyArray. Values whose
+// types do not match keep the value from a.
+func combineAttributes(a, b map[string]interface{}, strat_num, strat_string CombineStrategy) map[string]interface{} {
+	att := copyAttributes(a)
+	for key, newValue := range b {
+		existingValue, found := att[key]
+		if !found {
+			att[key] = newValue
+			continue
+		}
+		switch existingValue.(type) {
+		case int, []int:
+			if _, ok := newValue.(int); ok {
+				att[key] = strat_num.CombineInt(existingValue, newValue)
+			}
+		case float32, []float32:
+			if _, ok := newValue.(float32); ok {
+				att[key] = strat_num.CombineFloat32(existingValue, newValue)
+			}
+		case float64, []float64:
+			if _, ok := newValue.(float64); ok {
+				att[key] = strat_num.CombineFloat64(existingValue, newValue)
+			}
+		case string, []string:
+			if _, ok := newValue.(string); ok {
+				att[key] = strat_string.CombineString(existingValue, newValue)
+			}
+		}
+	}
+	return att
+}
+
 // Combines two nodes based on provided strategies
 func (g NewGraph) CombineNodes(n1, n2 NewNode, strat_num, strat_string CombineStrategy) NewNode {
 	id := ""
@@ -576,30 +687,7 @@ func (g NewGraph) CombineNodes(n1, n2 NewNode, strat_num, strat_string CombineSt
 		id += n2.ID + "_" + n1.ID
 	}
 
-	att := map[string]interface{}{}
-	for key, value := range n1.Attributes {
-		att[key] = value
-	}
-	for key, newValue := range n2.Attributes {
-		if existingValue, found := att[key]; found {
-			switch v := existingValue.(type) {
-			case int:
-				if nv, ok := newValue.(int); ok {
-					att[key] = strat_num.CombineInt(v, nv)
-				}
-			case float64:
-				if nv, ok := newValue.(float64); ok {
-					att[key] = strat_num.CombineFloat64(v, nv)
-				}
-			case string:
-				if nv, ok := newValue.(string); ok {
-					strat_string.CombineString(v, nv)
-				}
-			}
-		} else {
-			att[key] = newValue
-		}
-	}
+	att := combineAttributes(n1.Attributes, n2.Attributes, strat_num, strat_string)
 
 	return NewNode{ID: id, Attributes: att}
 }
@@ -646,7 +734,8 @@ func (g NewGraph) EdgeID(e NewEdge) string {
 	}
 }
 
-// Compares two edges
+// Compares two edges. In a multigraph, parallel edges only match if their
+// attributes are deeply equal.
 func (g NewGraph) CompareEdges(e1, e2 NewEdge) bool {
 	if !((CompareNodes(e1.First_node, e2.First_node) && CompareNodes(e1.Second_node, e2.Second_node)) || (CompareNodes(e1.First_node, e2.Second_node) && CompareNodes(e1.Second_node, e2.First_node))) {
 		return false
@@ -662,7 +751,8 @@ func (g NewGraph) CompareEdges(e1, e2 NewEdge) bool {
 
 	for key, value1 := range e1.Attributes {
 		value2, exists := e2.Attributes[key]
-		if !exists || value1 != value2 {
+		// values such as vectors and string lists are slices, which == cannot compare
+		if !exists || !reflect.DeepEqual(value1, value2) {
 			return false
 		}
 	}
@@ -670,82 +760,157 @@ func (g NewGraph) CompareEdges(e1, e2 NewEdge) bool {
 }
 
 // Tells if a graph elready has this edge
-func (g NewGraph) HasEdge(e NewEdge) bool {
-	for _, edge := range g.Edges {
-		if g.CompareEdges(e, edge) {
-			return true
+func (g *NewGraph) HasEdge(e NewEdge) bool {
+	_, found := g.findEdge(e)
+	return found
+}
+
+// Adds an edge to the graph and returns its key. In a multigraph every call
+// adds a new parallel edge; otherwise an existing edge between the same nodes
+// is updated with the attributes of the new one, summing int values.
+func (g *NewGraph) AddEdge(edge NewEdge) int {
+	if g.Type == "multigraph" {
+		// the lookup compares attribute values, which may not be comparable
+		return g.appendEdge(edge)
+	}
+	if edgeKey, found := g.findEdge(edge); found {
+		existing := g.Edges[edgeKey]
+		if existing.Attributes == nil {
+			existing.Attributes = map[string]interface{}{}
+			g.Edges[edgeKey] = existing
 		}
-	}
-	return false
-}
-
-// Adds an edge to the graph
-func (g *NewGraph) AddEdge(edge NewEdge) {
-	for _, value := range g.Edges {
-		if g.CompareEdges(edge, value) {
-			for key, value := range edge.Attributes {
-				existingvalue, isfound := g.GetEdge(edge).Attributes[key]
-				if isfound {
-					v, ok := existingvalue.(int)
-					u, okk := value.(int)
-					if ok && okk {
-						g.GetEdge(edge).Attributes[key] = v + u
-					} else {
-						g.GetEdge(edge).Attributes[key] = value
-					}
+		for key, value := range edge.Attributes {
+			existingvalue, isfound := existing.Attributes[key]
+			if isfound {
+				v, ok := existingvalue.(int)
+				u, okk := value.(int)
+				if ok && okk {
+					existing.Attributes[key] = v + u
 				} else {
-					g.GetEdge(edge).Attributes[key] = value
+					existing.Attributes[key] = value
 				}
+			} else {
+				existing.Attributes[key] = value
 			}
-			return
 		}
+		return edgeKey
 	}
+	return g.appendEdge(edge)
+}
+
+// appendEdge adds the edge and its missing nodes under a new key.
+func (g *NewGraph) appendEdge(edge NewEdge) int {
 	if !g.HasNode(edge.First_node) {
 		g.AddNode(edge.First_node)
 	}
 	if !g.HasNode(edge.Second_node) {
 		g.AddNode(edge.Second_node)
 	}
-	g.Edges[len(g.Edges)] = edge
+	key := g.newEdgeID()
+	g.setEdge(key, edge)
+	return key
 }
 
 // Adds all edges from provided slice to the graph
-func (g NewGraph) AddEdgesFrom(arr []NewEdge) NewGraph {
+func (g *NewGraph) AddEdgesFrom(arr []NewEdge) NewGraph {
 	for _, edge := range arr {
 		g.AddEdge(edge)
 	}
-	return g
+	return *g
 }
 
 // Returns edge from graph
-func (g NewGraph) GetEdge(e NewEdge) *NewEdge {
-	for _, edge := range g.Edges {
-		if g.CompareEdges(e, edge) {
-			return &edge
-		}
+func (g *NewGraph) GetEdge(e NewEdge) *NewEdge {
+	key, found := g.findEdge(e)
+	if !found {
+		return nil
 	}
-	return nil
+	edge := g.Edges[key]
+	return &edge
 }
 
-// Returns edge from the graph, that connects these two nodes
-func (g NewGraph) GetEdgeByNodes(n1, n2 NewNode) (NewEdge, int) {
-	for i, edge := range g.Edges {
+// Returns edge from the graph, that connects these two nodes, and its ID. If
+// there is no such edge, the returned ID is -1.
+func (g *NewGraph) GetEdgeByNodes(n1, n2 NewNode) (NewEdge, int) {
+	for _, i := range g.incidentEdges(n1.ID) {
+		edge := g.Edges[i]
 		if (CompareNodes(edge.First_node, n1) && CompareNodes(edge.Second_node, n2)) || (CompareNodes(edge.First_node, n2) && CompareNodes(edge.Second_node, n1)) {
 			return edge, i
 		}
 	}
 	fmt.Println("this graph does not have edge between these two nodes.")
-	return NewEdge{First_node: n1, Second_node: n2, Attributes: map[string]interface{}{}}, len(g.Edges)
+	return NewEdge{First_node: n1, Second_node: n2, Attributes: map[string]interface{}{}}, -1
+}
+
+// EdgeByID returns the edge with the given ID and whether it exists.
+func (g *NewGraph) EdgeByID(id int) (NewEdge, bool) {
+	edge, exists := g.Edges[id]
+	return edge, exists
+}
+
+// EdgesBetween returns all edges between the nodes with IDs u and v, in either
+// direction, ordered by key. In a graph that is not a multigraph there is at
+// most one.
+func (g *NewGraph) EdgesBetween(u, v string) []NewEdge {
+	keys := []int{}
+	for _, key := range g.incidentEdges(u) {
+		edge := g.Edges[key]
+		if (edge.First_node.ID == u && edge.Second_node.ID == v) || (edge.First_node.ID == v && edge.Second_node.ID == u) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Ints(keys)
+	edges := make([]NewEdge, len(keys))
+	for i, key := range keys {
+		edges[i] = g.Edges[key]
+	}
+	return edges
+}
+
+// ToSimple returns a copy of the multigraph in which all parallel edges between
+// two nodes are merged into one. Attributes that several parallel edges share
+// are combined with the provided strategy, in the order of the edge keys. The
+// returned graph has type "graph".
+func (g *NewGraph) ToSimple(strategy CombineStrategy) NewGraph {
+	simple := BasicGraph()
+	for id, node := range g.Nodes {
+		simple.Nodes[id] = NewNode{ID: node.ID, Attributes: copyAttributes(node.Attributes)}
+	}
+
+	keys := make([]int, 0, len(g.Edges))
+	for key := range g.Edges {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+
+	merged := make(map[string]int)
+	for _, key := range keys {
+		edge := g.Edges[key]
+		pair := endpointsKey(edge)
+		if simpleKey, found := merged[pair]; found {
+			existing := simple.Edges[simpleKey]
+			existing.Attributes = combineAttributes(existing.Attributes, edge.Attributes, strategy, strategy)
+			simple.Edges[simpleKey] = existing
+			continue
+		}
+		merged[pair] = simple.newEdgeID()
+		simple.setEdge(merged[pair], NewEdge{
+			First_node:  simple.Nodes[edge.First_node.ID],
+			Second_node: simple.Nodes[edge.Second_node.ID],
+			Attributes:  copyAttributes(edge.Attributes),
+		})
+	}
+	return simple
 }
 
 // Adds an attribute to the edge
-func (g NewGraph) AddEdgeAttribute(e NewEdge, att string, value interface{}) NewGraph {
+func (g *NewGraph) AddEdgeAttribute(e NewEdge, att string, value interface{}) NewGraph {
 	if g.GetEdge(e) == nil {
 		fmt.Println("this graph does not have this edge:", e)
-		return g
+		return *g
 	}
 	g.GetEdge(e).Attributes[att] = value
-	return g
+	return *g
 }
 
 // Returns the number of edges that this graph has
@@ -754,11 +919,104 @@ func (g NewGraph) NumberOfEdges() int {
 }
 
 // Removes edge from a graph
-func (g NewGraph) RemoveEdge(e NewEdge) NewGraph {
-	for i, edge := range g.Edges {
-		if g.CompareEdges(e, edge) {
-			delete(g.Edges, i)
+func (g *NewGraph) RemoveEdge(e NewEdge) NewGraph {
+	for _, key := range g.incidentEdges(e.First_node.ID) {
+		if g.CompareEdges(e, g.Edges[key]) {
+			g.RemoveEdgeByKey(key)
+		}
+	}
+	return *g
+}
+
+// edgeIndex returns the index from node IDs to the keys of their incident
+// edges, building it from Edges the first time it is needed.
+func (g *NewGraph) edgeIndex() map[string]map[int]struct{} {
+	if g.incidence == nil {
+		g.incidence = make(map[string]map[int]struct{}, len(g.Nodes))
+		for key, edge := range g.Edges {
+			g.indexEdge(key, edge)
+			if key >= g.nextEdgeID {
+				g.nextEdgeID = key + 1
+			}
 		}
 	}
-	return g
+	return g.incidence
+}
+
+// newEdgeID allocates the key for a new edge. IDs grow monotonically and skip
+// keys that are already taken, e.g. by edges added through a copy of the graph.
+func (g *NewGraph) newEdgeID() int {
+	g.edgeIndex()
+	for {
+		id := g.nextEdgeID
+		g.nextEdgeID++
+		if _, taken := g.Edges[id]; !taken {
+			return id
+		}
+	}
+}
+
+func (g *NewGraph) indexEdge(key int, edge NewEdge) {
+	for _, id := range []string{edge.First_node.ID, edge.Second_node.ID} {
+		if g.incidence[id] == nil {
+			g.incidence[id] = make(map[int]struct{})
+		}
+		g.incidence[id][key] = struct{}{}
+	}
+}
+
+func (g *NewGraph) unindexEdge(key int, edge NewEdge) {
+	for _, id := range []string{edge.First_node.ID, edge.Second_node.ID} {
+		delete(g.incidence[id], key)
+		if len(g.incidence[id]) == 0 {
+			delete(g.incidence, id)
+		}
+	}
+}
+
+// incidentEdges returns the keys of the edges incident to the node. The
+// returned slice is a copy, so edges may be removed while iterating over it.
+func (g *NewGraph) incidentEdges(id string) []int {
+	keys := make([]int, 0, len(g.edgeIndex()[id]))
+	for key := range g.incidence[id] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// findEdge returns the key of the first edge in the graph equal to e.
+func (g *NewGraph) findEdge(e NewEdge) (int, bool) {
+	for _, key := range g.incidentEdges(e.First_node.ID) {
+		if g.CompareEdges(e, g.Edges[key]) {
+			return key, true
+		}
+	}
+	return 0, false
+}
+
+// setEdge stores the edge under the given key and updates the index.
+func (g *NewGraph) setEdge(key int, edge NewEdge) {
+	g.edgeIndex()
+	if old, exists := g.Edges[key]; exists {
+		g.unindexEdge(key, old)
+	}
+	if g.Edges == nil {
+		g.Edges = make(map[int]NewEdge)
+	}
+	g.Edges[key] = edge
+	g.indexEdge(key, edge)
+}
+
+// RemoveEdgeByKey removes the edge with the given key, as returned by AddEdge,
+// and reports whether it existed. In a multigraph this removes a single one of
+// several parallel edges.
+func (g *NewGraph) RemoveEdgeByKey(key int) bool {
+	edge, exists := g.Edges[key]
+	if !exists {
+		return false
+	}
+	g.edgeIndex()
+	g.unindexEdge(key, edge)
+	delete(g.Edges, key)
+	return true
 }
@@ -0,0 +1,249 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func newTestNode(id string) NewNode {
+	return NewNode{ID: id, Attributes: map[string]interface{}{}}
+}
+
+// starNewGraph returns a graph with center "a" connected to "b", "c" and "d",
+// and an extra edge between "c" and "d".
+func starNewGraph() NewGraph {
+	g := BasicGraph()
+	for _, pair := range [][2]string{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"c", "d"}} {
+		g.AddEdge(NewEdge{
+			First_node:  newTestNode(pair[0]),
+			Second_node: newTestNode(pair[1]),
+			Attributes:  map[string]interface{}{},
+		})
+	}
+	return g
+}
+
+func neighborIDs(g *NewGraph, id string) []string {
+	ids := []string{}
+	for _, node := range g.Neighbors(newTestNode(id)) {
+		ids = append(ids, node.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestNewGraph_NeighborsAndDegree(t *testing.T) {
+	g := starNewGraph()
+
+	if ids := neighborIDs(&g, "a"); len(ids) != 3 || ids[0] != "b" || ids[2] != "d" {
+		t.Errorf("Expected neighbors [b c d], but got %v", ids)
+	}
+	if g.NodeDegree(newTestNode("c")) != 2 {
+		t.Errorf("Expected degree 2, but got %d", g.NodeDegree(newTestNode("c")))
+	}
+	if !g.HasEdge(NewEdge{First_node: newTestNode("d"), Second_node: newTestNode("c")}) {
+		t.Errorf("Expected edge c-d to exist")
+	}
+
+	// Test case 2: Adding an existing edge merges its attributes
+	g.AddEdge(NewEdge{First_node: newTestNode("b"), Second_node: newTestNode("a"), Attributes: map[string]interface{}{"weight": 2}})
+	g.AddEdge(NewEdge{First_node: newTestNode("a"), Second_node: newTestNode("b"), Attributes: map[string]interface{}{"weight": 3}})
+	if g.NumberOfEdges() != 4 {
+		t.Errorf("Expected 4 edges, but got %d", g.NumberOfEdges())
+	}
+	if e := g.GetEdge(NewEdge{First_node: newTestNode("a"), Second_node: newTestNode("b")}); e == nil || e.Attributes["weight"] != 5 {
+		t.Errorf("Expected merged weight 5, but got %v", e)
+	}
+}
+
+func TestNewGraph_RemoveNode(t *testing.T) {
+	g := starNewGraph()
+	g.RemoveNode(newTestNode("a"))
+
+	if g.NumberOfNodes() != 3 || g.NumberOfEdges() != 1 {
+		t.Errorf("Expected 3 nodes and 1 edge, but got %d and %d", g.NumberOfNodes(), g.NumberOfEdges())
+	}
+	if ids := neighborIDs(&g, "b"); len(ids) != 0 {
+		t.Errorf("Expected no neighbors of b, but got %v", ids)
+	}
+	if ids := neighborIDs(&g, "c"); len(ids) != 1 || ids[0] != "d" {
+		t.Errorf("Expected neighbors [d], but got %v", ids)
+	}
+}
+
+func TestNewGraph_CombineLeaves(t *testing.T) {
+	g := starNewGraph()
+	g.CombineLeaves()
+
+	if _, ok := g.Nodes["a_b"]; !ok || g.NumberOfNodes() != 3 {
+		t.Errorf("Expected leaf b merged into a_b, but got %v", g.Nodes)
+	}
+	if ids := neighborIDs(&g, "a_b"); len(ids) != 2 || ids[0] != "c" || ids[1] != "d" {
+		t.Errorf("Expected neighbors [c d] of a_b, but got %v", ids)
+	}
+}
+
+func TestNewGraph_ToJSON(t *testing.T) {
+	g := starNewGraph()
+	data, err := g.ToJSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(decoded) != 3 {
+		t.Errorf("Expected only Nodes, Edges and Type in the JSON, but got %v", decoded)
+	}
+}
+
+func TestNewGraph_StableEdgeIDs(t *testing.T) {
+	g := starNewGraph()
+	_, id := g.GetEdgeByNodes(newTestNode("c"), newTestNode("d"))
+
+	// Test case 1: Adding edges after a removal must not overwrite existing ones
+	g.RemoveEdge(NewEdge{First_node: newTestNode("a"), Second_node: newTestNode("b")})
+	g.AddEdge(NewEdge{First_node: newTestNode("b"), Second_node: newTestNode("c"), Attributes: map[string]interface{}{}})
+	g.AddEdge(NewEdge{First_node: newTestNode("b"), Second_node: newTestNode("d"), Attributes: map[string]interface{}{}})
+
+	if g.NumberOfEdges() != 5 {
+		t.Errorf("Expected 5 edges, but got %d", g.NumberOfEdges())
+	}
+	if edge, ok := g.EdgeByID(id); !ok || endpointsKey(edge) != endpointsKey(NewEdge{First_node: newTestNode("c"), Second_node: newTestNode("d")}) {
+		t.Errorf("Expected edge %d to still be c-d, but got %v", id, edge)
+	}
+	if _, ok := g.EdgeByID(100); ok {
+		t.Errorf("Expected no edge with ID 100")
+	}
+	if _, id := g.GetEdgeByNodes(newTestNode("a"), newTestNode("b")); id != -1 {
+		t.Errorf("Expected ID -1 for a missing edge, but got %d", id)
+	}
+
+	// Test case 2: Keys of removed edges are not reused after a JSON round trip
+	last := g.AddEdge(NewEdge{First_node: newTestNode("b"), Second_node: newTestNode("a"), Attributes: map[string]interface{}{}})
+	if !g.RemoveEdgeByKey(last) {
+		t.Fatalf("Expected edge %d to be removed", last)
+	}
+	data, err := g.ToJSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded NewGraph
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if key := decoded.AddEdge(NewEdge{First_node: newTestNode("b"), Second_node: newTestNode("a"), Attributes: map[string]interface{}{}}); key <= last {
+		t.Errorf("Expected a key above %d, but got %d", last, key)
+	}
+
+	// Test case 3: Repeated contraction keeps every remaining edge
+	g.ContractNewNode(newTestNode("a"))
+	g.ContractNewNode(newTestNode("b"))
+	if g.NumberOfNodes() != 2 || g.NumberOfEdges() != 1 {
+		t.Errorf("Expected 2 nodes and 1 edge, but got %d and %d", g.NumberOfNodes(), g.NumberOfEdges())
+	}
+}
+
+func TestNewGraph_IsEqual(t *testing.T) {
+	g := starNewGraph()
+
+	// the same edges inserted in a different order get different IDs
+	h := BasicGraph()
+	for _, pair := range [][2]string{{"d", "c"}, {"d", "a"}, {"b", "a"}, {"c", "a"}} {
+		h.AddEdge(NewEdge{First_node: newTestNode(pair[0]), Second_node: newTestNode(pair[1]), Attributes: map[string]interface{}{}})
+	}
+	if !g.IsEqual(h) {
+		t.Errorf("Expected graphs to be equal")
+	}
+
+	h.RemoveEdge(NewEdge{First_node: newTestNode("c"), Second_node: newTestNode("d")})
+	h.AddEdge(NewEdge{First_node: newTestNode("b"), Second_node: newTestNode("c"), Attributes: map[string]interface{}{}})
+	if g.IsEqual(h) {
+		t.Errorf("Expected graphs to differ")
+	}
+}
+
+func TestNewGraph_Combine(t *testing.T) {
+	g := starNewGraph()
+	h := BasicGraph()
+	h.AddEdge(NewEdge{First_node: newTestNode("d"), Second_node: newTestNode("e"), Attributes: map[string]interface{}{}})
+	h.AddEdge(NewEdge{First_node: newTestNode("c"), Second_node: newTestNode("a"), Attributes: map[string]interface{}{}})
+
+	combined := g.Combine(h)
+	if combined.NumberOfNodes() != 5 || combined.NumberOfEdges() != 5 {
+		t.Errorf("Expected 5 nodes and 5 edges, but got %d and %d", combined.NumberOfNodes(), combined.NumberOfEdges())
+	}
+}
+
+func TestNewGraph_MultiGraph(t *testing.T) {
+	g := MultiGraph()
+	a, b := newTestNode("a"), newTestNode("b")
+	first := g.AddEdge(NewEdge{First_node: a, Second_node: b, Attributes: map[string]interface{}{"weight": 1, "label": "x"}})
+	second := g.AddEdge(NewEdge{First_node: b, Second_node: a, Attributes: map[string]interface{}{"weight": 3, "label": "y"}})
+	g.AddEdge(NewEdge{First_node: a, Second_node: b, Attributes: map[string]interface{}{"weight": 1, "label": "x"}})
+	g.AddEdge(NewEdge{First_node: b, Second_node: newTestNode("c"), Attributes: map[string]interface{}{}})
+
+	if first == second || g.NumberOfEdges() != 4 {
+		t.Errorf("Expected 4 edges with distinct keys, but got %d", g.NumberOfEdges())
+	}
+	if edges := g.EdgesBetween("b", "a"); len(edges) != 3 || edges[1].Attributes["weight"] != 3 {
+		t.Errorf("Expected 3 parallel edges, but got %v", edges)
+	}
+	if g.NodeDegree(a) != 3 {
+		t.Errorf("Expected degree 3, but got %d", g.NodeDegree(a))
+	}
+
+	// Test case 2: Merging parallel edges with a strategy
+	simple := g.ToSimple(StrategyArray{})
+	if simple.Type != "graph" || simple.NumberOfEdges() != 2 {
+		t.Errorf("Expected a simple graph with 2 edges, but got %s with %d", simple.Type, simple.NumberOfEdges())
+	}
+	merged := simple.EdgesBetween("a", "b")[0]
+	if !reflect.DeepEqual(merged.Attributes["weight"], []int{1, 3, 1}) || !reflect.DeepEqual(merged.Attributes["label"], []string{"x", "y", "x"}) {
+		t.Errorf("Unexpected merged attributes %v", merged.Attributes)
+	}
+	averaged := g.ToSimple(StrategyAvgNum{})
+	if avg := averaged.EdgesBetween("a", "b")[0]; avg.Attributes["label"] != "x" || avg.Attributes["weight"] != 1 {
+		t.Errorf("Expected label x, but got %v", avg.Attributes["label"])
+	}
+
+	// Test case 3: Removing a single parallel edge by key
+	if !g.RemoveEdgeByKey(second) || g.RemoveEdgeByKey(second) {
+		t.Errorf("Expected edge %d to be removed exactly once", second)
+	}
+	if edges := g.EdgesBetween("a", "b"); len(edges) != 2 {
+		t.Errorf("Expected 2 parallel edges, but got %v", edges)
+	}
+
+	// Test case 4: Attributes that cannot be compared
+	tagged := MultiGraph()
+	tags := map[string]interface{}{"tags": []string{"x", "y"}}
+	tagged.AddEdge(NewEdge{First_node: a, Second_node: b, Attributes: tags})
+	tagged.AddEdge(NewEdge{First_node: a, Second_node: b, Attributes: tags})
+	if tagged.NumberOfEdges() != 2 {
+		t.Errorf("Expected 2 parallel edges, but got %d", tagged.NumberOfEdges())
+	}
+	vector := NewEdge{First_node: a, Second_node: b, Attributes: map[string]interface{}{"v": []float64{1}}}
+	tagged.AddEdge(vector)
+	if !tagged.HasEdge(vector) || tagged.HasEdge(NewEdge{First_node: a, Second_node: b, Attributes: map[string]interface{}{"v": []float64{2}}}) {
+		t.Errorf("Expected only the edge with vector [1] to be found")
+	}
+	if edge := tagged.GetEdge(vector); edge == nil || !reflect.DeepEqual(edge.Attributes["v"], []float64{1}) {
+		t.Errorf("Expected the edge with vector [1], but got %v", edge)
+	}
+	copied := MultiGraph()
+	copied.AddEdge(NewEdge{First_node: a, Second_node: b, Attributes: map[string]interface{}{"tags": []string{"x", "y"}}})
+	copied.AddEdge(NewEdge{First_node: a, Second_node: b, Attributes: map[string]interface{}{"tags": []string{"x", "y"}}})
+	copied.AddEdge(NewEdge{First_node: b, Second_node: a, Attributes: map[string]interface{}{"v": []float64{1}}})
+	if !tagged.IsEqual(copied) {
+		t.Errorf("Expected graphs with equal slice attributes to be equal")
+	}
+	tagged.RemoveEdge(vector)
+	if tagged.NumberOfEdges() != 2 || tagged.HasEdge(vector) {
+		t.Errorf("Expected the edge with vector [1] to be removed, but got %d edges", tagged.NumberOfEdges())
+	}
+}
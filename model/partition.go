@@ -0,0 +1,212 @@
+package model
+
+import (
+	"sort"
+)
+
+// Partition assigns every node of a graph to a community.
+type Partition map[Node]int
+
+// Communities groups the nodes of the partition by community. The nodes of
+// every community are sorted.
+func (p Partition) Communities() map[int][]Node {
+	communities := make(map[int][]Node)
+	for node, community := range p {
+		communities[community] = append(communities[community], node)
+	}
+	for _, nodes := range communities {
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+	}
+	return communities
+}
+
+// NumberOfCommunities returns the number of distinct communities in the partition.
+func (p Partition) NumberOfCommunities() int {
+	seen := make(map[int]bool)
+	for _, community := range p {
+		seen[community] = true
+	}
+	return len(seen)
+}
+
+// membershipOf returns the community of every node in the given order. Nodes
+// that the partition does not cover are put in communities of their own.
+func (p Partition) membershipOf(nodes []Node) []int {
+	next := 0
+	for _, community := range p {
+		if community >= next {
+			next = community + 1
+		}
+	}
+	membership := make([]int, len(nodes))
+	for i, node := range nodes {
+		community, ok := p[node]
+		if !ok {
+			community = next
+			next++
+		}
+		membership[i] = community
+	}
+	return membership
+}
+
+// partitionStats holds the edge counts needed by the partition quality metrics.
+// Self-loops count as intra-community edges.
+type partitionStats struct {
+	intra  float64
+	inter  float64
+	cut    map[int]float64
+	volume map[int]float64
+}
+
+func newPartitionStats(g *UndirectedGraph, p Partition) partitionStats {
+	stats := partitionStats{
+		cut:    make(map[int]float64),
+		volume: make(map[int]float64),
+	}
+	for node, neighbors := range g.Edges {
+		cu, ok := p[node]
+		if !ok || !g.Nodes[node] {
+			continue
+		}
+		for _, neighbor := range neighbors {
+			cv, ok := p[neighbor]
+			if !ok {
+				continue
+			}
+			stats.volume[cu]++
+			switch {
+			case cu != cv:
+				stats.cut[cu]++
+				if node < neighbor {
+					stats.inter++
+				}
+			case node < neighbor:
+				stats.intra++
+			case node == neighbor:
+				// a self-loop appears twice in the adjacency list of its node
+				stats.intra += 0.5
+			}
+		}
+	}
+	return stats
+}
+
+// Modularity returns the Newman modularity of the partition on g.
+//
+// Parameters:
+//   - g: The graph the partition was computed on.
+//   - p: The partition. Nodes of g that are not in p are treated as singleton communities.
+//   - resolution: The resolution parameter γ; 1 gives the standard modularity.
+//
+// Returns:
+//
+//	The modularity Q = Σ_c [ L_c/m - γ (d_c/2m)² ], where L_c is the number of
+//	edges inside community c and d_c the sum of the degrees of its nodes.
+func Modularity(g *UndirectedGraph, p Partition, resolution float64) float64 {
+	cg, nodes := newCommunityGraph(g, nil)
+	return cg.modularity(p.membershipOf(nodes), resolution)
+}
+
+// WeightedModularity returns the modularity of the partition on a weighted
+// graph, where edge counts and degrees are replaced by weights and strengths.
+func WeightedModularity(g *WeightedUndirectedGraph, p Partition, resolution float64) float64 {
+	cg, nodes := newCommunityGraph(&g.UndirectedGraph, g.Weight)
+	return cg.modularity(p.membershipOf(nodes), resolution)
+}
+
+// Coverage returns the fraction of the edges of g that lie inside communities.
+func Coverage(g *UndirectedGraph, p Partition) float64 {
+	stats := newPartitionStats(g, p)
+	if stats.intra+stats.inter == 0 {
+		return 0
+	}
+	return stats.intra / (stats.intra + stats.inter)
+}
+
+// Performance returns the fraction of node pairs that are classified correctly
+// by the partition: pairs inside a community that are connected plus pairs in
+// different communities that are not connected. Parallel edges and self-loops
+// are ignored.
+func Performance(g *UndirectedGraph, p Partition) float64 {
+	sizes := make(map[int]int)
+	n := 0
+	for node := range g.Nodes {
+		if community, ok := p[node]; ok {
+			sizes[community]++
+			n++
+		}
+	}
+	totalPairs := n * (n - 1) / 2
+	if totalPairs == 0 {
+		return 0
+	}
+	intraPairs := 0
+	for _, size := range sizes {
+		intraPairs += size * (size - 1) / 2
+	}
+
+	intraEdges, interEdges := 0, 0
+	for node := range g.Nodes {
+		cu, ok := p[node]
+		if !ok {
+			continue
+		}
+		seen := make(map[Node]bool, len(g.Edges[node]))
+		for _, neighbor := range g.Edges[node] {
+			cv, ok := p[neighbor]
+			if !ok || neighbor <= node || seen[neighbor] {
+				continue
+			}
+			seen[neighbor] = true
+			if cu == cv {
+				intraEdges++
+			} else {
+				interEdges++
+			}
+		}
+	}
+	interNonEdges := totalPairs - intraPairs - interEdges
+	return float64(intraEdges+interNonEdges) / float64(totalPairs)
+}
+
+// Conductance returns the conductance of every community, the number of edges
+// leaving the community divided by the smaller of the volumes of the community
+// and of the rest of the graph. Communities whose smaller volume is 0 have
+// conductance 0.
+func Conductance(g *UndirectedGraph, p Partition) map[int]float64 {
+	stats := newPartitionStats(g, p)
+	totalVolume := 0.0
+	for _, volume := range stats.volume {
+		totalVolume += volume
+	}
+
+	conductance := make(map[int]float64)
+	for _, community := range p {
+		volume := stats.volume[community]
+		denominator := volume
+		if rest := totalVolume - volume; rest < denominator {
+			denominator = rest
+		}
+		if denominator == 0 {
+			conductance[community] = 0
+			continue
+		}
+		conductance[community] = stats.cut[community] / denominator
+	}
+	return conductance
+}
+
+// NormalizedCut returns the k-way normalised cut of the partition, the sum
+// over all communities of the edges leaving the community divided by its
+// volume. Communities without edges do not contribute.
+func NormalizedCut(g *UndirectedGraph, p Partition) float64 {
+	stats := newPartitionStats(g, p)
+	ncut := 0.0
+	for community, volume := range stats.volume {
+		if volume > 0 {
+			ncut += stats.cut[community] / volume
+		}
+	}
+	return ncut
+}
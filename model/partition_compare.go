@@ -0,0 +1,145 @@
+package model
+
+import (
+	"fmt"
+	"math"
+)
+
+// contingencyTable counts how the nodes of two partitions of the same node set
+// overlap.
+type contingencyTable struct {
+	n     int
+	cells map[[2]int]int
+	rows  map[int]int
+	cols  map[int]int
+}
+
+func newContingencyTable(a, b Partition) (*contingencyTable, error) {
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("partitions cover different node sets: %d and %d nodes", len(a), len(b))
+	}
+	table := &contingencyTable{
+		n:     len(a),
+		cells: make(map[[2]int]int),
+		rows:  make(map[int]int),
+		cols:  make(map[int]int),
+	}
+	for node, ca := range a {
+		cb, ok := b[node]
+		if !ok {
+			return nil, fmt.Errorf("node %d is missing from the second partition", node)
+		}
+		table.cells[[2]int{ca, cb}]++
+		table.rows[ca]++
+		table.cols[cb]++
+	}
+	return table, nil
+}
+
+func pairs(n int) float64 {
+	return float64(n) * float64(n-1) / 2
+}
+
+func (t *contingencyTable) entropy(counts map[int]int) float64 {
+	h := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(t.n)
+		h -= p * math.Log(p)
+	}
+	return h
+}
+
+func (t *contingencyTable) mutualInformation() float64 {
+	mi := 0.0
+	for cell, count := range t.cells {
+		pab := float64(count) / float64(t.n)
+		pa := float64(t.rows[cell[0]]) / float64(t.n)
+		pb := float64(t.cols[cell[1]]) / float64(t.n)
+		mi += pab * math.Log(pab/(pa*pb))
+	}
+	return mi
+}
+
+// pairCounts returns the number of node pairs that are together in both
+// partitions, only in the first and only in the second.
+func (t *contingencyTable) pairCounts() (both, onlyA, onlyB float64) {
+	for _, count := range t.cells {
+		both += pairs(count)
+	}
+	sumA, sumB := 0.0, 0.0
+	for _, count := range t.rows {
+		sumA += pairs(count)
+	}
+	for _, count := range t.cols {
+		sumB += pairs(count)
+	}
+	return both, sumA - both, sumB - both
+}
+
+// NormalizedMutualInformation returns the mutual information of two partitions
+// of the same node set, normalised by the arithmetic mean of their entropies.
+// The result is 1 for identical partitions and close to 0 for independent ones.
+func NormalizedMutualInformation(a, b Partition) (float64, error) {
+	table, err := newContingencyTable(a, b)
+	if err != nil {
+		return 0, err
+	}
+	ha, hb := table.entropy(table.rows), table.entropy(table.cols)
+	if ha+hb == 0 {
+		return 1, nil
+	}
+	return 2 * table.mutualInformation() / (ha + hb), nil
+}
+
+// VariationOfInformation returns the variation of information between two
+// partitions of the same node set, H(a) + H(b) - 2 I(a, b), in nats. It is a
+// metric on partitions and equals 0 for identical partitions.
+func VariationOfInformation(a, b Partition) (float64, error) {
+	table, err := newContingencyTable(a, b)
+	if err != nil {
+		return 0, err
+	}
+	vi := table.entropy(table.rows) + table.entropy(table.cols) - 2*table.mutualInformation()
+	return math.Max(vi, 0), nil
+}
+
+// AdjustedRandIndex returns the Rand index of two partitions of the same node
+// set, adjusted for chance. The result is 1 for identical partitions, close to
+// 0 for random ones and can be negative.
+//
+// References: [1] Hubert, L. and Arabie, P., "Comparing partitions",
+// Journal of Classification, 2, 193-218, 1985.
+func AdjustedRandIndex(a, b Partition) (float64, error) {
+	table, err := newContingencyTable(a, b)
+	if err != nil {
+		return 0, err
+	}
+	both, onlyA, onlyB := table.pairCounts()
+	sumA, sumB := both+onlyA, both+onlyB
+	total := pairs(table.n)
+	if total == 0 {
+		return 1, nil
+	}
+	expected := sumA * sumB / total
+	maximum := (sumA + sumB) / 2
+	if maximum == expected {
+		return 1, nil
+	}
+	return (both - expected) / (maximum - expected), nil
+}
+
+// JaccardIndex returns the Jaccard similarity of the co-membership relations of
+// two partitions of the same node set: the number of node pairs that share a
+// community in both partitions divided by the number of pairs that share a
+// community in at least one of them.
+func JaccardIndex(a, b Partition) (float64, error) {
+	table, err := newContingencyTable(a, b)
+	if err != nil {
+		return 0, err
+	}
+	both, onlyA, onlyB := table.pairCounts()
+	if both+onlyA+onlyB == 0 {
+		return 1, nil
+	}
+	return both / (both + onlyA + onlyB), nil
+}
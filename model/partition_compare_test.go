@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestPartitionComparison(t *testing.T) {
+	a := Partition{0: 0, 1: 0, 2: 0, 3: 1, 4: 1, 5: 1}
+	b := Partition{0: 0, 1: 0, 2: 1, 3: 1, 4: 2, 5: 2}
+
+	testCases := []struct {
+		name     string
+		metric   func(a, b Partition) (float64, error)
+		expected float64
+		identity float64
+	}{
+		{"NMI", NormalizedMutualInformation, 0.5158037429793888, 1},
+		{"VI", VariationOfInformation, 0.8675632284814613, 0},
+		{"ARI", AdjustedRandIndex, 0.24242424242424243, 1},
+		{"Jaccard", JaccardIndex, 2.0 / 7.0, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.metric(a, b)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !almostEqual(result, tc.expected) {
+				t.Errorf("Expected %v, but got %v", tc.expected, result)
+			}
+
+			// Relabelling communities must not change the score of identical partitions
+			relabelled := Partition{}
+			for node, community := range a {
+				relabelled[node] = community + 10
+			}
+			result, err = tc.metric(a, relabelled)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !almostEqual(result, tc.identity) {
+				t.Errorf("Expected %v for identical partitions, but got %v", tc.identity, result)
+			}
+
+			// Partitions of different node sets are rejected
+			if _, err := tc.metric(a, Partition{0: 0, 1: 0, 2: 0, 3: 1, 4: 1, 9: 1}); err == nil {
+				t.Errorf("Expected an error for different node sets")
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func twoCliquesPartition() Partition {
+	p := Partition{}
+	for i := 0; i < 10; i++ {
+		p[Node(i)] = i / 5
+	}
+	return p
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestPartition_Communities(t *testing.T) {
+	p := Partition{3: 1, 1: 0, 2: 1, 0: 0}
+	expected := map[int][]Node{0: {0, 1}, 1: {2, 3}}
+	if result := p.Communities(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+	if p.NumberOfCommunities() != 2 {
+		t.Errorf("Expected 2 communities, but got %d", p.NumberOfCommunities())
+	}
+}
+
+func TestModularity(t *testing.T) {
+	g := twoCliques()
+	expected := 2 * (10.0/21.0 - 0.25)
+	if result := Modularity(g, twoCliquesPartition(), 1); !almostEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	// Test case 2: A single community has modularity 0
+	single := Partition{}
+	for node := range g.Nodes {
+		single[node] = 0
+	}
+	if result := Modularity(g, single, 1); !almostEqual(result, 0) {
+		t.Errorf("Expected 0, but got %v", result)
+	}
+}
+
+func TestCoverageAndPerformance(t *testing.T) {
+	g := twoCliques()
+	p := twoCliquesPartition()
+	if result := Coverage(g, p); !almostEqual(result, 20.0/21.0) {
+		t.Errorf("Expected coverage %v, but got %v", 20.0/21.0, result)
+	}
+	if result := Performance(g, p); !almostEqual(result, 44.0/45.0) {
+		t.Errorf("Expected performance %v, but got %v", 44.0/45.0, result)
+	}
+}
+
+func TestConductanceAndNormalizedCut(t *testing.T) {
+	g := twoCliques()
+	p := twoCliquesPartition()
+	conductance := Conductance(g, p)
+	for community, value := range conductance {
+		if !almostEqual(value, 1.0/21.0) {
+			t.Errorf("Expected conductance %v for community %d, but got %v", 1.0/21.0, community, value)
+		}
+	}
+	if result := NormalizedCut(g, p); !almostEqual(result, 2.0/21.0) {
+		t.Errorf("Expected normalized cut %v, but got %v", 2.0/21.0, result)
+	}
+}
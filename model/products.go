@@ -0,0 +1,162 @@
+package model
+
+import "sort"
+
+// Graph products combine two factor graphs G and H into a graph on the pairs
+// (u, v) of a node u of G and a node v of H. The pair of the i-th node of G and
+// the j-th node of H, in increasing order, is labelled with the Node
+// j*|G| + i, so the nodes of G vary fastest. With this numbering, the
+// Cartesian product of PathGraph(n) and CompleteGraph(2) is exactly
+// LadderGraph(n). Every product also returns the pair of factor nodes of each
+// of its nodes. Self-loops and parallel edges in the factors are ignored.
+
+// productFactor holds the sorted nodes of a factor, the index of every node,
+// and its edges between distinct nodes once each, as pairs of indices.
+type productFactor struct {
+	nodes []Node
+	index map[Node]int
+	edges [][2]int
+}
+
+func newProductFactor(g *UndirectedGraph) productFactor {
+	nodes := GetDictKeys(g.Nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+	index := make(map[Node]int, len(nodes))
+	for i, node := range nodes {
+		index[node] = i
+	}
+	f := productFactor{nodes: nodes, index: index}
+	for edge := range edgeMultiplicities(g) {
+		if edge.Node1 != edge.Node2 {
+			f.edges = append(f.edges, [2]int{index[edge.Node1], index[edge.Node2]})
+		}
+	}
+	return f
+}
+
+// productGraph is a product under construction.
+type productGraph struct {
+	g, h productFactor
+	*UndirectedGraph
+}
+
+func newProductGraph(g, h *UndirectedGraph) productGraph {
+	p := productGraph{g: newProductFactor(g), h: newProductFactor(h), UndirectedGraph: &UndirectedGraph{}}
+	for j := range p.h.nodes {
+		for i := range p.g.nodes {
+			p.AddNode(p.node(i, j))
+		}
+	}
+	return p
+}
+
+// node returns the product node of the i-th node of G and the j-th node of H.
+func (p productGraph) node(i, j int) Node {
+	return Node(j*len(p.g.nodes) + i)
+}
+
+func (p productGraph) connect(i1, j1, i2, j2 int) {
+	p.AddEdge(Edge{Node1: p.node(i1, j1), Node2: p.node(i2, j2)})
+}
+
+// addCartesianEdges connects (u, v) and (u', v) for every edge u-u' of G, and
+// (u, v) and (u, v') for every edge v-v' of H.
+func (p productGraph) addCartesianEdges() {
+	for _, edge := range p.g.edges {
+		for j := range p.h.nodes {
+			p.connect(edge[0], j, edge[1], j)
+		}
+	}
+	p.addLayerEdges()
+}
+
+// addLayerEdges connects (u, v) and (u, v') for every edge v-v' of H.
+func (p productGraph) addLayerEdges() {
+	for _, edge := range p.h.edges {
+		for i := range p.g.nodes {
+			p.connect(i, edge[0], i, edge[1])
+		}
+	}
+}
+
+// addTensorEdges connects (u, v) and (u', v') for every edge u-u' of G and
+// every edge v-v' of H.
+func (p productGraph) addTensorEdges() {
+	for _, edgeG := range p.g.edges {
+		for _, edgeH := range p.h.edges {
+			p.connect(edgeG[0], edgeH[0], edgeG[1], edgeH[1])
+			p.connect(edgeG[0], edgeH[1], edgeG[1], edgeH[0])
+		}
+	}
+}
+
+// labels returns the pair of factor nodes of every product node.
+func (p productGraph) labels() map[Node][2]Node {
+	labels := make(map[Node][2]Node, len(p.g.nodes)*len(p.h.nodes))
+	for j, v := range p.h.nodes {
+		for i, u := range p.g.nodes {
+			labels[p.node(i, j)] = [2]Node{u, v}
+		}
+	}
+	return labels
+}
+
+/*
+CartesianProduct returns the Cartesian product G □ H, in which (u, v) and (u', v') are connected if
+u = u' and v is connected to v' in H, or v = v' and u is connected to u' in G.
+
+Parameters:
+- g, h: The factors G and H.
+
+Returns:
+- *UndirectedGraph: The product, with |G|·|H| nodes and |G|·e(H) + |H|·e(G) edges.
+- map[Node][2]Node: The pair of nodes of G and H of every node of the product.
+
+Example:
+
+	// a 3x4 grid
+	grid, labels := CartesianProduct(PathGraph(3), PathGraph(4))
+
+	fmt.Println(labels[4]) // Output: [1 1]
+*/
+func CartesianProduct(g, h *UndirectedGraph) (*UndirectedGraph, map[Node][2]Node) {
+	p := newProductGraph(g, h)
+	p.addCartesianEdges()
+	return p.UndirectedGraph, p.labels()
+}
+
+// TensorProduct returns the tensor (categorical, direct) product G × H, in
+// which (u, v) and (u', v') are connected if u is connected to u' in G and v is
+// connected to v' in H. It also returns the pair of factor nodes of every node.
+func TensorProduct(g, h *UndirectedGraph) (*UndirectedGraph, map[Node][2]Node) {
+	p := newProductGraph(g, h)
+	p.addTensorEdges()
+	return p.UndirectedGraph, p.labels()
+}
+
+// StrongProduct returns the strong product G ⊠ H, the union of the Cartesian
+// and the tensor product. It also returns the pair of factor nodes of every
+// node.
+func StrongProduct(g, h *UndirectedGraph) (*UndirectedGraph, map[Node][2]Node) {
+	p := newProductGraph(g, h)
+	p.addCartesianEdges()
+	p.addTensorEdges()
+	return p.UndirectedGraph, p.labels()
+}
+
+// LexicographicProduct returns the lexicographic product G[H], in which (u, v)
+// and (u', v') are connected if u is connected to u' in G, or u = u' and v is
+// connected to v' in H: every node of G is replaced by a copy of H. It also
+// returns the pair of factor nodes of every node.
+func LexicographicProduct(g, h *UndirectedGraph) (*UndirectedGraph, map[Node][2]Node) {
+	p := newProductGraph(g, h)
+	for _, edge := range p.g.edges {
+		for j1 := range p.h.nodes {
+			for j2 := range p.h.nodes {
+				p.connect(edge[0], j1, edge[1], j2)
+			}
+		}
+	}
+	p.addLayerEdges()
+	return p.UndirectedGraph, p.labels()
+}
@@ -0,0 +1,84 @@
+package model
+
+import "testing"
+
+func TestCartesianProduct(t *testing.T) {
+	// LadderGraph and CircularLadderGraph are products with K_2
+	ladder, labels := CartesianProduct(PathGraph(5), CompleteGraph(2))
+	if !ladder.Equals(LadderGraph(5)) {
+		t.Errorf("Expected %v, but got %v", LadderGraph(5), ladder)
+	}
+	if labels[7] != [2]Node{2, 1} {
+		t.Errorf("Expected [2 1], but got %v", labels[7])
+	}
+	circular, _ := CartesianProduct(CycleGraph(4), CompleteGraph(2))
+	expected, _ := CircularLadderGraph(4)
+	if !circular.Equals(expected) {
+		t.Errorf("Expected %v, but got %v", expected, circular)
+	}
+}
+
+func TestProducts(t *testing.T) {
+	testCases := []struct {
+		name        string
+		product     func(g, h *UndirectedGraph) (*UndirectedGraph, map[Node][2]Node)
+		edges       int
+		has, hasNot Edge
+	}{
+		// P_3 and P_2: 6 nodes, labelled (u, v) -> 3*v + u
+		{"cartesian", CartesianProduct, 7, Edge{Node1: 0, Node2: 3}, Edge{Node1: 0, Node2: 4}},
+		{"tensor", TensorProduct, 4, Edge{Node1: 0, Node2: 4}, Edge{Node1: 0, Node2: 3}},
+		{"strong", StrongProduct, 11, Edge{Node1: 1, Node2: 5}, Edge{Node1: 0, Node2: 2}},
+		{"lexicographic", LexicographicProduct, 11, Edge{Node1: 3, Node2: 1}, Edge{Node1: 0, Node2: 5}},
+	}
+	for _, tc := range testCases {
+		product, labels := tc.product(PathGraph(3), PathGraph(2))
+		if product.NumberOfNodes() != 6 || product.NumberOfEdges() != tc.edges || len(labels) != 6 {
+			t.Errorf("%s: Expected 6 nodes and %d edges, but got %d and %d", tc.name, tc.edges, product.NumberOfNodes(), product.NumberOfEdges())
+		}
+		if !product.HasEdge(tc.has.Node1, tc.has.Node2) || product.HasEdge(tc.hasNot.Node1, tc.hasNot.Node2) {
+			t.Errorf("%s: Expected edge %v but not %v, got %v", tc.name, tc.has, tc.hasNot, product.Edges)
+		}
+	}
+
+	// Test case 2: The lexicographic product is not commutative
+	a, _ := LexicographicProduct(PathGraph(2), edgelessGraph(2))
+	b, _ := LexicographicProduct(edgelessGraph(2), PathGraph(2))
+	if a.NumberOfEdges() != 4 || b.NumberOfEdges() != 2 {
+		t.Errorf("Expected 4 and 2 edges, but got %d and %d", a.NumberOfEdges(), b.NumberOfEdges())
+	}
+}
+
+func TestGridTorusHypercube(t *testing.T) {
+	grid := GridGraph(3, 4)
+	if grid.NumberOfNodes() != 12 || grid.NumberOfEdges() != 17 || !grid.HasEdge(5, 9) || !grid.HasEdge(5, 6) || grid.HasEdge(3, 4) {
+		t.Errorf("Expected a 3x4 grid, but got %v", grid)
+	}
+	if line := GridGraph(1, 3); line.NumberOfNodes() != 3 || line.NumberOfEdges() != 2 {
+		t.Errorf("Expected 3 nodes and 2 edges, but got %d and %d", line.NumberOfNodes(), line.NumberOfEdges())
+	}
+
+	torus, err := TorusGraph(3, 4)
+	if err != nil || torus.NumberOfEdges() != 24 || !torus.HasEdge(0, 3) || !torus.HasEdge(0, 8) {
+		t.Errorf("Expected a 3x4 torus, but got %v", torus)
+	}
+	if _, err := TorusGraph(2, 4); err == nil {
+		t.Errorf("Expected an error for a torus with 2 rows")
+	}
+
+	cube := HypercubeGraph(3)
+	if cube.NumberOfNodes() != 8 || cube.NumberOfEdges() != 12 || !cube.HasEdge(5, 7) || cube.HasEdge(3, 5) {
+		t.Errorf("Expected the 3-cube, but got %v", cube)
+	}
+	if HypercubeGraph(0).NumberOfNodes() != 1 {
+		t.Errorf("Expected 1 node, but got %d", HypercubeGraph(0).NumberOfNodes())
+	}
+}
+
+func edgelessGraph(numberOfNodes int) *UndirectedGraph {
+	g := &UndirectedGraph{}
+	for i := 0; i < numberOfNodes; i++ {
+		g.AddNode(Node(i))
+	}
+	return g
+}
@@ -0,0 +1,193 @@
+package model
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// NodeOrdering selects the order in which ConvertToIntegers numbers the nodes
+// of a graph.
+type NodeOrdering int
+
+const (
+	// OrderSorted numbers the nodes in increasing order of their keys.
+	OrderSorted NodeOrdering = iota
+	// OrderDegree numbers the nodes by decreasing degree, breaking ties by key.
+	OrderDegree
+	// OrderBFS numbers the nodes in breadth-first order, which keeps neighbours
+	// close to each other. Each component is traversed from its smallest node,
+	// visiting neighbours in increasing order.
+	OrderBFS
+)
+
+// NodeOrder returns the nodes of the graph in the given order. Edges of
+// directed graphs are followed in both directions.
+func NodeOrder[K cmp.Ordered](g GraphView[K], ordering NodeOrdering) ([]K, error) {
+	nodes := g.NodeIDs()
+	slices.Sort(nodes)
+	switch ordering {
+	case OrderSorted:
+		return nodes, nil
+	case OrderDegree:
+		adjacency := undirectedAdjacency(g)
+		degrees := make(map[K]int, len(nodes))
+		for _, node := range nodes {
+			degrees[node] = len(adjacency(node))
+		}
+		slices.SortStableFunc(nodes, func(a, b K) int { return degrees[b] - degrees[a] })
+		return nodes, nil
+	case OrderBFS:
+		adjacency := undirectedAdjacency(g)
+		visited := make(map[K]bool, len(nodes))
+		order := make([]K, 0, len(nodes))
+		for _, start := range nodes {
+			if visited[start] {
+				continue
+			}
+			visited[start] = true
+			order = append(order, start)
+			for head := len(order) - 1; head < len(order); head++ {
+				neighbors := append([]K(nil), adjacency(order[head])...)
+				slices.Sort(neighbors)
+				for _, neighbor := range neighbors {
+					if !visited[neighbor] {
+						visited[neighbor] = true
+						order = append(order, neighbor)
+					}
+				}
+			}
+		}
+		return order, nil
+	}
+	return nil, fmt.Errorf("unknown node ordering %d", ordering)
+}
+
+/*
+ConvertToIntegers numbers the nodes of a graph from 0 to n-1, e.g. to compact the labels of a sampled
+graph or to export a graph to index-based formats.
+
+Parameters:
+- g: The graph, e.g. an UndirectedGraph or a NewGraph. Directed graphs are converted to undirected ones.
+- ordering: The order in which the nodes are numbered.
+
+Returns:
+- *UndirectedGraph: The relabelled graph. It keeps the adjacency policy of an UndirectedGraph.
+- map[K]Node: The new label of every node.
+- []K: The inverse mapping, the original node of every new label.
+- error: An error if the ordering is unknown.
+
+Example:
+
+	g := PathGraph(5)
+	g.RemoveNode(2)
+	compact, mapping, inverse, _ := ConvertToIntegers[Node](g, OrderSorted)
+
+	fmt.Println(mapping[3], inverse[2]) // Output: 2 3
+*/
+func ConvertToIntegers[K cmp.Ordered](g GraphView[K], ordering NodeOrdering) (*UndirectedGraph, map[K]Node, []K, error) {
+	inverse, err := NodeOrder(g, ordering)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	mapping := make(map[K]Node, len(inverse))
+	for i, node := range inverse {
+		mapping[node] = Node(i)
+	}
+
+	ng := &UndirectedGraph{Nodes: make(map[Node]bool, len(inverse)), Edges: make(map[Node][]Node, len(inverse))}
+	if ug, ok := any(g).(*UndirectedGraph); ok {
+		ng.DisallowMultiEdges, ng.DisallowSelfLoops = ug.DisallowMultiEdges, ug.DisallowSelfLoops
+	}
+	for i := range inverse {
+		ng.AddNode(Node(i))
+	}
+	CopyGraph[K, Node](ng, g, func(node K) Node { return mapping[node] })
+	return ng, mapping, inverse, nil
+}
+
+// RelabelNodes returns a copy of the graph in which every node in the mapping
+// gets its new label, while the other nodes keep theirs. It returns an error if
+// two nodes would get the same label.
+func (g *UndirectedGraph) RelabelNodes(mapping map[Node]Node) (*UndirectedGraph, error) {
+	label := func(node Node) Node {
+		if newLabel, ok := mapping[node]; ok {
+			return newLabel
+		}
+		return node
+	}
+
+	ng := &UndirectedGraph{
+		Nodes:              make(map[Node]bool, len(g.Nodes)),
+		Edges:              make(map[Node][]Node, len(g.Edges)),
+		DisallowMultiEdges: g.DisallowMultiEdges,
+		DisallowSelfLoops:  g.DisallowSelfLoops,
+	}
+	for node := range g.Nodes {
+		if ng.Nodes[label(node)] {
+			return nil, fmt.Errorf("several nodes would be relabelled to %d", label(node))
+		}
+		ng.Nodes[label(node)] = true
+	}
+	for node, neighbors := range g.Edges {
+		relabelled := make([]Node, len(neighbors))
+		for i, neighbor := range neighbors {
+			relabelled[i] = label(neighbor)
+		}
+		ng.Edges[label(node)] = relabelled
+	}
+	return ng, nil
+}
+
+// RelabelNodes returns a copy of the graph in which every node in the mapping
+// gets its new ID, while the other nodes keep theirs. Attributes are copied and
+// edges keep their keys. It returns an error if two nodes would get the same ID.
+func (g *NewGraph) RelabelNodes(mapping map[string]string) (NewGraph, error) {
+	ng := NewGraph{
+		Nodes:      make(map[string]NewNode, len(g.Nodes)),
+		Edges:      make(map[int]NewEdge, len(g.Edges)),
+		Type:       g.Type,
+		NodeSchema: g.NodeSchema,
+		EdgeSchema: g.EdgeSchema,
+	}
+	label := func(id string) string {
+		if newID, ok := mapping[id]; ok {
+			return newID
+		}
+		return id
+	}
+	for id, node := range g.Nodes {
+		if _, taken := ng.Nodes[label(id)]; taken {
+			return NewGraph{}, fmt.Errorf("several nodes would be relabelled to %s", label(id))
+		}
+		ng.Nodes[label(id)] = NewNode{ID: label(id), Attributes: copyAttributes(node.Attributes)}
+	}
+	for key, edge := range g.Edges {
+		ng.setEdge(key, NewEdge{
+			First_node:  ng.Nodes[label(edge.First_node.ID)],
+			Second_node: ng.Nodes[label(edge.Second_node.ID)],
+			Attributes:  copyAttributes(edge.Attributes),
+		})
+	}
+	return ng, nil
+}
+
+// ConvertToIntegers returns a copy of the graph whose node IDs are the numbers
+// "0" to "n-1" in the given order, with all attributes, so that the ID of every
+// node is its row in index-based formats such as edge index tensors. It also
+// returns the index of every original ID and the original ID of every index.
+func (g *NewGraph) ConvertToIntegers(ordering NodeOrdering) (NewGraph, map[string]int, []string, error) {
+	inverse, err := NodeOrder[string](g, ordering)
+	if err != nil {
+		return NewGraph{}, nil, nil, err
+	}
+	index := make(map[string]int, len(inverse))
+	mapping := make(map[string]string, len(inverse))
+	for i, id := range inverse {
+		index[id] = i
+		mapping[id] = strconv.Itoa(i)
+	}
+	ng, err := g.RelabelNodes(mapping)
+	return ng, index, inverse, err
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConvertToIntegers(t *testing.T) {
+	// a star with center 10 and a separate edge, with sparse labels
+	g := &UndirectedGraph{}
+	g.AddEdgesFromIntTupleList([][2]int{{10, 3}, {10, 7}, {10, 20}, {30, 40}})
+
+	testCases := []struct {
+		ordering NodeOrdering
+		expected []Node
+	}{
+		{OrderSorted, []Node{3, 7, 10, 20, 30, 40}},
+		{OrderDegree, []Node{10, 3, 7, 20, 30, 40}},
+		{OrderBFS, []Node{3, 10, 7, 20, 30, 40}},
+	}
+	for _, tc := range testCases {
+		compact, mapping, inverse, err := ConvertToIntegers[Node](g, tc.ordering)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if !reflect.DeepEqual(inverse, tc.expected) {
+			t.Errorf("Expected %v, but got %v", tc.expected, inverse)
+		}
+		for node, label := range mapping {
+			if inverse[label] != node {
+				t.Errorf("Expected %v, but got %v", node, inverse[label])
+			}
+		}
+		if compact.NumberOfNodes() != 6 || compact.NumberOfEdges() != 4 || !compact.HasEdge(mapping[10], mapping[20]) {
+			t.Errorf("Expected the relabelled graph, but got %v", compact)
+		}
+	}
+
+	// Test case 2: Unknown orderings are rejected
+	if _, _, _, err := ConvertToIntegers[Node](g, NodeOrdering(42)); err == nil {
+		t.Errorf("Expected an error for an unknown ordering")
+	}
+}
+
+func TestUndirectedGraph_RelabelNodes(t *testing.T) {
+	g := PathGraph(3)
+	relabelled, err := g.RelabelNodes(map[Node]Node{0: 5, 2: 0})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	expectedEdges := map[Node][]Node{5: {1}, 1: {5, 0}, 0: {1}}
+	if !reflect.DeepEqual(relabelled.Edges, expectedEdges) {
+		t.Errorf("Expected %v, but got %v", expectedEdges, relabelled.Edges)
+	}
+
+	// Test case 2: Two nodes cannot get the same label
+	if _, err := g.RelabelNodes(map[Node]Node{0: 1}); err == nil {
+		t.Errorf("Expected an error for clashing labels")
+	}
+}
+
+func TestNewGraph_ConvertToIntegers(t *testing.T) {
+	g := starNewGraph()
+	g.SetNodeAttribute("c", "year", 2020)
+	g.SetEdgeAttribute("c", "d", "weight", 2)
+
+	compact, index, inverse, err := g.ConvertToIntegers(OrderDegree)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !reflect.DeepEqual(inverse, []string{"a", "c", "d", "b"}) || index["b"] != 3 {
+		t.Errorf("Expected [a c d b], but got %v", inverse)
+	}
+	if year, _ := NodeAttribute[int](&compact, "1", "year"); year != 2020 {
+		t.Errorf("Expected 2020, but got %v", year)
+	}
+	if weight, _ := EdgeAttribute[int](&compact, "1", "2", "weight"); weight != 2 || len(compact.Edges) != 4 {
+		t.Errorf("Expected weight 2, but got %v", weight)
+	}
+	if _, err := g.RelabelNodes(map[string]string{"a": "b"}); err == nil {
+		t.Errorf("Expected an error for clashing IDs")
+	}
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 	"sort"
 
@@ -42,9 +43,11 @@ type DeletionRandomWalkWithRestartSampling struct{ IDeletionSamplingStrategy }
 
 func (strategy *DeletionRandomNodeSampling) SamplingStage(g *UndirectedGraph, howMany int) error {
 	nodes := GetDictKeys(g.Nodes)
-	for _, node := range rand.Perm(len(nodes))[:howMany] {
-		g.RemoveNode(nodes[node])
+	toRemove := make([]Node, howMany)
+	for i, node := range rand.Perm(len(nodes))[:howMany] {
+		toRemove[i] = nodes[node]
 	}
+	g.RemoveNodes(toRemove)
 	return nil
 }
 
@@ -91,9 +94,11 @@ func (strategy *DeletionRandomDegreeNodeSampling) SamplingStage(g *UndirectedGra
 func (strategy *DeletionRandomEdgeSampling) SamplingStage(g *UndirectedGraph, howManyToDelete int) error {
 	edges := g.GetEdgeTuples()
 
-	for _, edgeIndex := range rand.Perm(len(edges))[:howManyToDelete] {
-		g.RemoveEdge(edges[edgeIndex])
+	toRemove := make([]Edge, howManyToDelete)
+	for i, edgeIndex := range rand.Perm(len(edges))[:howManyToDelete] {
+		toRemove[i] = edges[edgeIndex]
 	}
+	g.RemoveEdges(toRemove)
 	return nil
 }
 
@@ -249,18 +254,10 @@ func (strategy *PreservationRandomNodeSampling) Sample(g UndirectedGraph, sample
 	var selectedNodes []Node
 
 	for _, node := range rand.Perm(len(nodes))[:expectedFinalGraphSize] {
-		ng.AddNode(nodes[node])
 		selectedNodes = append(selectedNodes, nodes[node])
 	}
 
-	for i := 0; i < len(selectedNodes); i++ {
-		neighbors := g.Edges[selectedNodes[i]]
-		for _, neighbor := range neighbors {
-			if ng.HasNode(neighbor) {
-				ng.AddEdge(Edge{Node1: selectedNodes[i], Node2: neighbor})
-			}
-		}
-	}
+	InducedSubgraph[Node](&g, selectedNodes).Materialize(&ng)
 	return ng, nil
 }
 
@@ -326,7 +323,6 @@ func (strategy *PreservationRandomDegreeNodeSampling) Sample(g UndirectedGraph,
 		}
 		pick := choice.Pick()
 		if !selectedNodes[pick.(Node)] {
-			ng.AddNode(pick.(Node))
 			selectedNodes[pick.(Node)] = true
 			selectedNodesArray = append(selectedNodesArray, pick.(Node))
 			nodesCounter = nodesCounter + 1
@@ -336,14 +332,31 @@ func (strategy *PreservationRandomDegreeNodeSampling) Sample(g UndirectedGraph,
 		}
 	}
 
-	for i := 0; i < len(selectedNodesArray); i++ {
-		neighbors := g.Edges[selectedNodesArray[i]]
-		for _, neighbor := range neighbors {
-			if ng.HasNode(neighbor) {
-				ng.AddEdge(Edge{Node1: selectedNodesArray[i], Node2: neighbor})
-			}
-		}
+	InducedSubgraph[Node](&g, selectedNodesArray).Materialize(&ng)
+	return ng, nil
+}
+
+// Sample keeps nodes drawn without replacement with probability proportional
+// to their PageRank, and returns the subgraph they induce, with the adjacency
+// policy of the graph. The ratio must be between 0 and 1.
+func (strategy *RandomPageRankNodeSampling) Sample(graph *UndirectedGraph, sampledGraphSizeRatio float32) (*UndirectedGraph, error) {
+	if sampledGraphSizeRatio < 0 || sampledGraphSizeRatio > 1 {
+		return nil, fmt.Errorf("sampled graph size ratio must be between 0 and 1, got %v", sampledGraphSizeRatio)
+	}
+	rank, err := PageRank[Node](graph, DefaultPageRankOptions[Node]())
+	if err != nil {
+		return nil, fmt.Errorf("error computing PageRank: %w", err)
+	}
+	expectedFinalGraphSize := int(float32(len(graph.Nodes)) * sampledGraphSizeRatio)
+	selectedNodes := weightedNodeOrder(rank, func(r float64) float64 { return r })[:expectedFinalGraphSize]
+
+	ng := &UndirectedGraph{
+		Nodes:              make(map[Node]bool),
+		Edges:              make(map[Node][]Node),
+		DisallowMultiEdges: graph.DisallowMultiEdges,
+		DisallowSelfLoops:  graph.DisallowSelfLoops,
 	}
+	InducedSubgraph[Node](graph, selectedNodes).Materialize(ng)
 	return ng, nil
 }
 
@@ -843,6 +856,45 @@ func (strategy *ContractionRandomWalkWithJumpSampling) Sample(graph UndirectedGr
 	return ng, nil
 }
 
+// Sample contracts nodes until the graph has the expected size, drawing them
+// without replacement with probability inversely proportional to their
+// PageRank in the original graph, so that peripheral nodes go first.
+func (strategy *ContractionPageRankNodeSampling) Sample(graph *UndirectedGraph, sampledGraphSizeRatio float32) (*UndirectedGraph, error) {
+	rank, err := PageRank[Node](graph, DefaultPageRankOptions[Node]())
+	if err != nil {
+		return nil, fmt.Errorf("error computing PageRank: %w", err)
+	}
+	ng := &UndirectedGraph{DisallowMultiEdges: graph.DisallowMultiEdges, DisallowSelfLoops: graph.DisallowSelfLoops}
+	CopyGraph[Node, Node](ng, graph, func(node Node) Node { return node })
+
+	expectedFinalGraphSize := int(float32(len(graph.Nodes)) * sampledGraphSizeRatio)
+	for _, node := range weightedNodeOrder(rank, func(r float64) float64 { return 1 / r }) {
+		if len(ng.Nodes) <= expectedFinalGraphSize {
+			break
+		}
+		ng.ContractNode(node)
+	}
+	return ng, nil
+}
+
+// weightedNodeOrder returns the nodes in a random order in which every prefix
+// is a sample without replacement with probabilities proportional to
+// weight(score).
+//
+// References:
+//   - Efraimidis, P. S., & Spirakis, P. G. (2006). Weighted random sampling with a reservoir.
+//     Information Processing Letters, 97(5), 181-185.
+func weightedNodeOrder(scores map[Node]float64, weight func(float64) float64) []Node {
+	keys := make(map[Node]float64, len(scores))
+	nodes := make([]Node, 0, len(scores))
+	for node, score := range scores {
+		keys[node] = math.Pow(rand.Float64(), 1/weight(score))
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return keys[nodes[i]] > keys[nodes[j]] })
+	return nodes
+}
+
 // Helper method to pick a random node from the graph
 func (g *UndirectedGraph) pickRandomNode() Node {
 	var nodes []Node
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestPageRankNodeSampling(t *testing.T) {
+	g := LollipopGraph(6, 6)
+	var sampler ISamplingStrategy = &RandomPageRankNodeSampling{}
+	sample, err := sampler.Sample(g, 0.5)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(sample.Nodes) != len(g.Nodes)/2 {
+		t.Errorf("Expected %d nodes, but got %d", len(g.Nodes)/2, len(sample.Nodes))
+	}
+	for node := range sample.Nodes {
+		for _, neighbor := range g.Edges[node] {
+			if sample.Nodes[neighbor] && !sample.HasEdge(node, neighbor) {
+				t.Errorf("Expected the sample to be an induced subgraph, missing edge %d-%d", node, neighbor)
+			}
+		}
+	}
+
+	// the sample keeps the adjacency policy of the graph
+	simple := NewSimpleGraph()
+	CopyGraph[Node, Node](simple, g, func(node Node) Node { return node })
+	if sample, _ := sampler.Sample(simple, 1); !sample.DisallowMultiEdges || !sample.DisallowSelfLoops {
+		t.Errorf("Expected a simple sample of a simple graph")
+	}
+	for _, ratio := range []float32{-0.5, 1.5} {
+		if _, err := sampler.Sample(g, ratio); err == nil {
+			t.Errorf("Expected an error for ratio %v", ratio)
+		}
+	}
+
+	// Test case 2: Contraction
+	nodes, edges := len(g.Nodes), g.NumberOfEdges()
+	sampler = &ContractionPageRankNodeSampling{}
+	contracted, err := sampler.Sample(g, 0.5)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(contracted.Nodes) != nodes/2 {
+		t.Errorf("Expected %d nodes, but got %d", nodes/2, len(contracted.Nodes))
+	}
+	// contraction keeps a connected graph connected
+	if components := ComponentsOf[Node](contracted); len(components) != 1 {
+		t.Errorf("Expected 1 component, but got %d", len(components))
+	}
+	if len(g.Nodes) != nodes || g.NumberOfEdges() != edges {
+		t.Errorf("Expected the input graph to be unchanged")
+	}
+}
+
+func TestPreservationNodeSampling(t *testing.T) {
+	g := CycleGraph(6)
+
+	// keeping every node keeps every edge exactly once
+	sample, err := (&PreservationRandomNodeSampling{}).Sample(*g, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(sample.Nodes) != 6 || sample.NumberOfEdges() != 6 {
+		t.Errorf("Expected 6 nodes and 6 edges, but got %d and %d", len(sample.Nodes), sample.NumberOfEdges())
+	}
+
+	// Test case 2: Degree-weighted sampling
+	sample, err = (&PreservationRandomDegreeNodeSampling{}).Sample(*g, 0.5)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(sample.Nodes) != 3 {
+		t.Errorf("Expected 3 nodes, but got %d", len(sample.Nodes))
+	}
+	for node := range sample.Nodes {
+		for _, neighbor := range g.Edges[node] {
+			if sample.Nodes[neighbor] && !sample.HasEdge(node, neighbor) {
+				t.Errorf("Expected the sample to be an induced subgraph, missing edge %d-%d", node, neighbor)
+			}
+		}
+	}
+}
@@ -0,0 +1,412 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Set operations treat a graph as a set of nodes and a multiset of edges. Two
+// edges match if they connect the same nodes, in the same direction for
+// directed graphs. For graphs without parallel edges this gives the usual set
+// semantics; parallel edges are matched one to one, so e.g. the union keeps the
+// larger number of parallel edges between two nodes.
+
+// edgeMultiplicities counts the edges of an UndirectedGraph by their endpoints,
+// with the smaller node first.
+func edgeMultiplicities(g *UndirectedGraph) map[Edge]int {
+	counts := make(map[Edge]int)
+	for node, neighbors := range g.Edges {
+		for _, neighbor := range neighbors {
+			if node < neighbor {
+				counts[Edge{node, neighbor}]++
+			} else if node == neighbor {
+				// a self-loop appears twice in the adjacency list of its node
+				counts[Edge{node, node}]++
+			}
+		}
+	}
+	for edge, count := range counts {
+		if edge.Node1 == edge.Node2 {
+			counts[edge] = count / 2
+		}
+	}
+	return counts
+}
+
+// combineUndirected builds the graph whose nodes are selected by keepNode and
+// in which every pair of nodes is connected by multiplicity(countG, countH)
+// parallel edges.
+func combineUndirected(g, h *UndirectedGraph, keepNode func(inG, inH bool) bool, multiplicity func(countG, countH int) int) *UndirectedGraph {
+	k := &UndirectedGraph{
+		Nodes:              make(map[Node]bool),
+		Edges:              make(map[Node][]Node),
+		DisallowMultiEdges: g.DisallowMultiEdges && h.DisallowMultiEdges,
+		DisallowSelfLoops:  g.DisallowSelfLoops && h.DisallowSelfLoops,
+	}
+	for node := range g.Nodes {
+		if keepNode(true, h.Nodes[node]) {
+			k.AddNode(node)
+		}
+	}
+	for node := range h.Nodes {
+		if keepNode(g.Nodes[node], true) {
+			k.AddNode(node)
+		}
+	}
+
+	countsG, countsH := edgeMultiplicities(g), edgeMultiplicities(h)
+	pairs := make(map[Edge]bool, len(countsG)+len(countsH))
+	for edge := range countsG {
+		pairs[edge] = true
+	}
+	for edge := range countsH {
+		pairs[edge] = true
+	}
+	for edge := range pairs {
+		if !k.Nodes[edge.Node1] || !k.Nodes[edge.Node2] {
+			continue
+		}
+		for i := multiplicity(countsG[edge], countsH[edge]); i > 0; i-- {
+			k.appendEdge(edge)
+		}
+	}
+	return k
+}
+
+/*
+Union returns the graph with the nodes and edges of both graphs.
+
+Parameters:
+- g, h: The graphs to combine.
+
+Returns:
+- *UndirectedGraph: A new graph. It allows parallel edges and self-loops if one of the graphs does.
+
+Example:
+
+	g := PathGraph(3) // 0-1-2
+	h := &UndirectedGraph{}
+	h.AddEdge(Edge{Node1: 2, Node2: 3})
+
+	fmt.Println(Union(g, h).NumberOfEdges()) // Output: 3
+*/
+func Union(g, h *UndirectedGraph) *UndirectedGraph {
+	return combineUndirected(g, h,
+		func(inG, inH bool) bool { return true },
+		func(countG, countH int) int { return max(countG, countH) },
+	)
+}
+
+// Intersection returns the graph with the nodes and edges that both graphs have.
+func Intersection(g, h *UndirectedGraph) *UndirectedGraph {
+	return combineUndirected(g, h,
+		func(inG, inH bool) bool { return inG && inH },
+		func(countG, countH int) int { return min(countG, countH) },
+	)
+}
+
+// Difference returns the graph with the nodes of g and the edges of g that h
+// does not have.
+func Difference(g, h *UndirectedGraph) *UndirectedGraph {
+	return combineUndirected(g, h,
+		func(inG, inH bool) bool { return inG },
+		func(countG, countH int) int { return max(countG-countH, 0) },
+	)
+}
+
+// SymmetricDifference returns the graph with the nodes of both graphs and the
+// edges that only one of them has.
+func SymmetricDifference(g, h *UndirectedGraph) *UndirectedGraph {
+	return combineUndirected(g, h,
+		func(inG, inH bool) bool { return true },
+		func(countG, countH int) int { return max(countG-countH, countH-countG) },
+	)
+}
+
+/*
+DisjointUnion returns the graph with the nodes and edges of both graphs, where the nodes of h are
+relabelled so that they do not clash with the nodes of g.
+
+Parameters:
+- g, h: The graphs to combine.
+
+Returns:
+- *UndirectedGraph: A new graph. The nodes of g keep their labels, while the nodes of h are numbered
+consecutively, in increasing order, from the largest node of g plus one.
+- map[Node]Node: The new label of every node of h.
+*/
+func DisjointUnion(g, h *UndirectedGraph) (*UndirectedGraph, map[Node]Node) {
+	offset := Node(0)
+	for node := range g.Nodes {
+		if node >= offset {
+			offset = node + 1
+		}
+	}
+	nodes := GetDictKeys(h.Nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+	mapping := make(map[Node]Node, len(nodes))
+	for i, node := range nodes {
+		mapping[node] = offset + Node(i)
+	}
+
+	relabelled := &UndirectedGraph{
+		Nodes:              make(map[Node]bool, len(nodes)),
+		Edges:              make(map[Node][]Node, len(h.Edges)),
+		DisallowMultiEdges: h.DisallowMultiEdges,
+		DisallowSelfLoops:  h.DisallowSelfLoops,
+	}
+	for node := range h.Nodes {
+		relabelled.Nodes[mapping[node]] = true
+	}
+	for node, neighbors := range h.Edges {
+		for _, neighbor := range neighbors {
+			relabelled.Edges[mapping[node]] = append(relabelled.Edges[mapping[node]], mapping[neighbor])
+		}
+	}
+	return Union(g, relabelled), mapping
+}
+
+// Complement returns the graph with the nodes of g in which two distinct nodes
+// are connected if and only if they are not connected in g.
+func Complement(g *UndirectedGraph) *UndirectedGraph {
+	k := &UndirectedGraph{Nodes: make(map[Node]bool, len(g.Nodes)), Edges: make(map[Node][]Node, len(g.Nodes))}
+	nodes := GetDictKeys(g.Nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+	for i, u := range nodes {
+		k.AddNode(u)
+		for _, v := range nodes[i+1:] {
+			if !g.HasEdge(u, v) {
+				k.appendEdge(Edge{Node1: u, Node2: v})
+			}
+		}
+	}
+	return k
+}
+
+// pairKey identifies the pair of nodes an edge of the graph connects, ordered
+// for directed graphs and unordered otherwise.
+func (g *NewGraph) pairKey(e NewEdge) string {
+	if g.Type == "digraph" {
+		return e.First_node.ID + "\x00" + e.Second_node.ID
+	}
+	return endpointsKey(e)
+}
+
+// parallelEdges groups the keys of the edges of the graph by pairKey, in
+// increasing order within every group.
+func (g *NewGraph) parallelEdges() map[string][]int {
+	keys := make([]int, 0, len(g.Edges))
+	for key := range g.Edges {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	groups := make(map[string][]int)
+	for _, key := range keys {
+		pair := g.pairKey(g.Edges[key])
+		groups[pair] = append(groups[pair], key)
+	}
+	return groups
+}
+
+// combineNewGraphs builds the graph whose nodes are selected by keepNode.
+// Parallel edges between the same pair of nodes are matched in the order of
+// their keys. Matched edges are merged into one if keepMatched is set, and the
+// unmatched edges of g and h are kept if keepG and keepH are set. Nodes and
+// edges that both graphs have get the attributes of both, combined with the
+// strategy. Edges from g keep their keys, while those only in h get new ones.
+func combineNewGraphs(g, h NewGraph, strategy CombineStrategy, keepNode func(inG, inH bool) bool, keepMatched, keepG, keepH bool) (NewGraph, error) {
+	if g.Type != h.Type {
+		return NewGraph{}, fmt.Errorf("graphs must be the same type, got %q and %q", g.Type, h.Type)
+	}
+	k := NewGraph{
+		Nodes:      map[string]NewNode{},
+		Edges:      map[int]NewEdge{},
+		Type:       g.Type,
+		NodeSchema: g.NodeSchema,
+		EdgeSchema: g.EdgeSchema,
+	}
+
+	for id, node := range g.Nodes {
+		other, inH := h.Nodes[id]
+		switch {
+		case !keepNode(true, inH):
+		case inH:
+			k.Nodes[id] = NewNode{ID: id, Attributes: combineAttributes(node.Attributes, other.Attributes, strategy, strategy)}
+		default:
+			k.Nodes[id] = NewNode{ID: id, Attributes: copyAttributes(node.Attributes)}
+		}
+	}
+	for id, node := range h.Nodes {
+		if _, inG := g.Nodes[id]; !inG && keepNode(false, true) {
+			k.Nodes[id] = NewNode{ID: id, Attributes: copyAttributes(node.Attributes)}
+		}
+	}
+
+	addEdge := func(key int, edge NewEdge, attributes map[string]interface{}) {
+		first, ok1 := k.Nodes[edge.First_node.ID]
+		second, ok2 := k.Nodes[edge.Second_node.ID]
+		if ok1 && ok2 {
+			k.setEdge(key, NewEdge{First_node: first, Second_node: second, Attributes: attributes})
+		}
+	}
+
+	groupsG, groupsH := g.parallelEdges(), h.parallelEdges()
+	pairs := make([]string, 0, len(groupsG)+len(groupsH))
+	for pair := range groupsG {
+		pairs = append(pairs, pair)
+	}
+	for pair := range groupsH {
+		if _, inG := groupsG[pair]; !inG {
+			pairs = append(pairs, pair)
+		}
+	}
+	sort.Strings(pairs)
+
+	for _, pair := range pairs {
+		keysG, keysH := groupsG[pair], groupsH[pair]
+		matched := min(len(keysG), len(keysH))
+		if keepMatched {
+			for i := 0; i < matched; i++ {
+				addEdge(keysG[i], g.Edges[keysG[i]], combineAttributes(g.Edges[keysG[i]].Attributes, h.Edges[keysH[i]].Attributes, strategy, strategy))
+			}
+		}
+		if keepG {
+			for _, key := range keysG[matched:] {
+				addEdge(key, g.Edges[key], copyAttributes(g.Edges[key].Attributes))
+			}
+		}
+	}
+	// the edges of h get new keys only after all keys of g are taken
+	if keepH {
+		for _, pair := range pairs {
+			keysG, keysH := groupsG[pair], groupsH[pair]
+			for _, key := range keysH[min(len(keysG), len(keysH)):] {
+				addEdge(k.newEdgeID(), h.Edges[key], copyAttributes(h.Edges[key].Attributes))
+			}
+		}
+	}
+	return k, nil
+}
+
+// NewGraphUnion returns the graph with the nodes and edges of both graphs.
+// Nodes and edges that both graphs have get the attributes of both, where
+// values set in both are combined with the strategy. The graphs must have the
+// same type.
+func NewGraphUnion(g, h NewGraph, strategy CombineStrategy) (NewGraph, error) {
+	return combineNewGraphs(g, h, strategy, func(inG, inH bool) bool { return true }, true, true, true)
+}
+
+// NewGraphIntersection returns the graph with the nodes and edges that both
+// graphs have, with their attributes combined as in NewGraphUnion.
+func NewGraphIntersection(g, h NewGraph, strategy CombineStrategy) (NewGraph, error) {
+	return combineNewGraphs(g, h, strategy, func(inG, inH bool) bool { return inG && inH }, true, false, false)
+}
+
+// NewGraphDifference returns the graph with the nodes of g and the edges of g
+// that h does not have. Nodes that both graphs have get their attributes
+// combined as in NewGraphUnion.
+func NewGraphDifference(g, h NewGraph, strategy CombineStrategy) (NewGraph, error) {
+	return combineNewGraphs(g, h, strategy, func(inG, inH bool) bool { return inG }, false, true, false)
+}
+
+// NewGraphSymmetricDifference returns the graph with the nodes of both graphs
+// and the edges that only one of them has. Nodes that both graphs have get
+// their attributes combined as in NewGraphUnion.
+func NewGraphSymmetricDifference(g, h NewGraph, strategy CombineStrategy) (NewGraph, error) {
+	return combineNewGraphs(g, h, strategy, func(inG, inH bool) bool { return true }, false, true, true)
+}
+
+/*
+NewGraphDisjointUnion returns the graph with the nodes and edges of both graphs, where the nodes of h
+whose IDs g already uses are relabelled.
+
+Parameters:
+- g, h: The graphs to combine. They must have the same type.
+
+Returns:
+- NewGraph: A new graph. A clashing node ID of h gets the smallest suffix "_1", "_2", ... that makes it unique.
+- map[string]string: The new ID of every node of h.
+- error: An error if the graphs have different types.
+*/
+func NewGraphDisjointUnion(g, h NewGraph) (NewGraph, map[string]string, error) {
+	if g.Type != h.Type {
+		return NewGraph{}, nil, fmt.Errorf("graphs must be the same type, got %q and %q", g.Type, h.Type)
+	}
+	ids := make([]string, 0, len(h.Nodes))
+	for id := range h.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	taken := make(map[string]bool, len(g.Nodes)+len(h.Nodes))
+	for id := range g.Nodes {
+		taken[id] = true
+	}
+	for _, id := range ids {
+		taken[id] = true
+	}
+	mapping := make(map[string]string, len(ids))
+	for _, id := range ids {
+		newID := id
+		if _, clash := g.Nodes[id]; clash {
+			for suffix := 1; taken[newID]; suffix++ {
+				newID = fmt.Sprintf("%s_%d", id, suffix)
+			}
+		}
+		taken[newID] = true
+		mapping[id] = newID
+	}
+
+	relabelled := NewGraph{Nodes: map[string]NewNode{}, Edges: map[int]NewEdge{}, Type: h.Type}
+	for id, node := range h.Nodes {
+		relabelled.Nodes[mapping[id]] = NewNode{ID: mapping[id], Attributes: node.Attributes}
+	}
+	for key, edge := range h.Edges {
+		relabelled.Edges[key] = NewEdge{
+			First_node:  relabelled.Nodes[mapping[edge.First_node.ID]],
+			Second_node: relabelled.Nodes[mapping[edge.Second_node.ID]],
+			Attributes:  edge.Attributes,
+		}
+	}
+	// no node or edge is in both graphs, so no attributes need to be combined
+	union, err := NewGraphUnion(g, relabelled, nil)
+	return union, mapping, err
+}
+
+// NewGraphComplement returns the graph with the nodes of g, including their
+// attributes, in which two distinct nodes are connected if and only if they are
+// not connected in g. For digraphs, edges are considered in both directions.
+// The new edges have no attributes.
+func NewGraphComplement(g NewGraph) NewGraph {
+	k := NewGraph{
+		Nodes:      map[string]NewNode{},
+		Edges:      map[int]NewEdge{},
+		Type:       g.Type,
+		NodeSchema: g.NodeSchema,
+		EdgeSchema: g.EdgeSchema,
+	}
+	ids := make([]string, 0, len(g.Nodes))
+	for id, node := range g.Nodes {
+		ids = append(ids, id)
+		k.Nodes[id] = NewNode{ID: id, Attributes: copyAttributes(node.Attributes)}
+	}
+	sort.Strings(ids)
+
+	connected := make(map[string]bool, len(g.Edges))
+	for _, edge := range g.Edges {
+		connected[g.pairKey(edge)] = true
+	}
+	directed := g.Type == "digraph"
+	for i, u := range ids {
+		for j, v := range ids {
+			if i == j || (!directed && j < i) {
+				continue
+			}
+			edge := NewEdge{First_node: k.Nodes[u], Second_node: k.Nodes[v], Attributes: map[string]interface{}{}}
+			if !connected[g.pairKey(edge)] {
+				k.setEdge(k.newEdgeID(), edge)
+			}
+		}
+	}
+	return k
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestUndirectedSetOperations(t *testing.T) {
+	// a path 0-1-2-3 and a triangle 2-3-4
+	g := PathGraph(4)
+	h := &UndirectedGraph{}
+	h.AddEdgesFromIntTupleList([][2]int{{2, 3}, {3, 4}, {4, 2}})
+
+	testCases := []struct {
+		name         string
+		graph        *UndirectedGraph
+		nodes, edges int
+		has, hasNot  Edge
+	}{
+		{"union", Union(g, h), 5, 5, Edge{Node1: 4, Node2: 2}, Edge{Node1: 0, Node2: 2}},
+		{"intersection", Intersection(g, h), 2, 1, Edge{Node1: 2, Node2: 3}, Edge{Node1: 1, Node2: 2}},
+		{"difference", Difference(g, h), 4, 2, Edge{Node1: 1, Node2: 2}, Edge{Node1: 2, Node2: 3}},
+		{"symmetric difference", SymmetricDifference(g, h), 5, 4, Edge{Node1: 3, Node2: 4}, Edge{Node1: 2, Node2: 3}},
+		{"complement", Complement(g), 4, 3, Edge{Node1: 0, Node2: 3}, Edge{Node1: 0, Node2: 1}},
+	}
+	for _, tc := range testCases {
+		if tc.graph.NumberOfNodes() != tc.nodes || tc.graph.NumberOfEdges() != tc.edges {
+			t.Errorf("%s: Expected %d nodes and %d edges, but got %d and %d", tc.name, tc.nodes, tc.edges, tc.graph.NumberOfNodes(), tc.graph.NumberOfEdges())
+		}
+		if !tc.graph.HasEdge(tc.has.Node1, tc.has.Node2) || tc.graph.HasEdge(tc.hasNot.Node1, tc.hasNot.Node2) {
+			t.Errorf("%s: Expected edge %v but not %v, got %v", tc.name, tc.has, tc.hasNot, tc.graph.Edges)
+		}
+	}
+
+	// Test case 2: Parallel edges are matched one to one
+	multi := &UndirectedGraph{}
+	multi.AddEdgesFromIntTupleList([][2]int{{0, 1}, {0, 1}, {1, 2}})
+	if Union(g, multi).NumberOfEdges() != 4 || Difference(multi, g).NumberOfEdges() != 1 {
+		t.Errorf("Expected 4 and 1 edges, but got %d and %d", Union(g, multi).NumberOfEdges(), Difference(multi, g).NumberOfEdges())
+	}
+}
+
+func TestDisjointUnion(t *testing.T) {
+	union, mapping := DisjointUnion(PathGraph(3), CycleGraph(3))
+	if union.NumberOfNodes() != 6 || union.NumberOfEdges() != 5 {
+		t.Errorf("Expected 6 nodes and 5 edges, but got %d and %d", union.NumberOfNodes(), union.NumberOfEdges())
+	}
+	if mapping[0] != 3 || mapping[2] != 5 || !union.HasEdge(3, 5) || union.HasEdge(2, 3) {
+		t.Errorf("Expected the cycle on nodes 3, 4 and 5, but got %v", mapping)
+	}
+}
+
+func TestNewGraphSetOperations(t *testing.T) {
+	g := starNewGraph()
+	g.Nodes["a"].Attributes["weight"] = 1
+	g.SetEdgeAttribute("a", "b", "weight", 2)
+
+	h := BasicGraph()
+	for _, pair := range [][2]string{{"b", "a"}, {"d", "e"}} {
+		h.AddEdge(NewEdge{First_node: newTestNode(pair[0]), Second_node: newTestNode(pair[1]), Attributes: map[string]interface{}{"weight": 4}})
+	}
+	h.Nodes["a"].Attributes["weight"] = 3
+
+	union, err := NewGraphUnion(g, h, StrategyRetainMax{})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(union.Nodes) != 5 || len(union.Edges) != 5 {
+		t.Errorf("Expected 5 nodes and 5 edges, but got %d and %d", len(union.Nodes), len(union.Edges))
+	}
+	if weight, _ := EdgeAttribute[int](&union, "a", "b", "weight"); weight != 4 {
+		t.Errorf("Expected weight 4, but got %v", weight)
+	}
+	if weight, _ := NodeAttribute[int](&union, "a", "weight"); weight != 3 {
+		t.Errorf("Expected weight 3, but got %v", weight)
+	}
+
+	intersection, _ := NewGraphIntersection(g, h, StrategyAvgNum{})
+	if len(intersection.Nodes) != 3 || len(intersection.Edges) != 1 {
+		t.Errorf("Expected 3 nodes and 1 edge, but got %d and %d", len(intersection.Nodes), len(intersection.Edges))
+	}
+	if weight, _ := EdgeAttribute[int](&intersection, "a", "b", "weight"); weight != 3 {
+		t.Errorf("Expected weight 3, but got %v", weight)
+	}
+
+	difference, _ := NewGraphDifference(g, h, StrategyAvgNum{})
+	symmetric, _ := NewGraphSymmetricDifference(g, h, StrategyAvgNum{})
+	if len(difference.Edges) != 3 || difference.HasNode(newTestNode("e")) || len(symmetric.Edges) != 4 {
+		t.Errorf("Expected 3 and 4 edges, but got %d and %d", len(difference.Edges), len(symmetric.Edges))
+	}
+
+	// Test case 2: Graphs of different types cannot be combined
+	if _, err := NewGraphUnion(g, DiGraph(), StrategyAvgNum{}); err == nil {
+		t.Errorf("Expected an error for graphs of different types")
+	}
+}
+
+func TestNewGraphDisjointUnionAndComplement(t *testing.T) {
+	g := starNewGraph()
+	h := BasicGraph()
+	h.AddEdge(NewEdge{First_node: newTestNode("a"), Second_node: newTestNode("b_1"), Attributes: map[string]interface{}{}})
+	h.AddEdge(NewEdge{First_node: newTestNode("b"), Second_node: newTestNode("z"), Attributes: map[string]interface{}{}})
+
+	union, mapping, err := NewGraphDisjointUnion(g, h)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if mapping["a"] != "a_1" || mapping["b"] != "b_2" || mapping["b_1"] != "b_1" || mapping["z"] != "z" {
+		t.Errorf("Expected a_1, b_2, b_1 and z, but got %v", mapping)
+	}
+	if len(union.Nodes) != 8 || len(union.Edges) != 6 || len(union.EdgesBetween("a_1", "b_1")) != 1 {
+		t.Errorf("Expected 8 nodes and 6 edges, but got %d and %d", len(union.Nodes), len(union.Edges))
+	}
+
+	complement := NewGraphComplement(g)
+	if len(complement.Edges) != 2 || len(complement.EdgesBetween("b", "d")) != 1 || len(complement.EdgesBetween("a", "b")) != 0 {
+		t.Errorf("Expected the edges b-c and b-d, but got %v", complement.ToString())
+	}
+}
@@ -0,0 +1,323 @@
+package model
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNegativeCycle is returned by BellmanFord when a negative cycle can be
+// reached from the source, so shortest paths are not defined.
+var ErrNegativeCycle = errors.New("graph contains a negative cycle")
+
+// ErrNoPath is returned by the point-to-point searches when the target cannot
+// be reached from the source.
+var ErrNoPath = errors.New("target is not reachable from source")
+
+// WeightFunc returns the weight of the edge from u to v. In undirected graphs
+// it must be symmetric.
+type WeightFunc[K comparable] func(u, v K) float64
+
+// unitWeight gives every edge weight 1, so paths are measured in hops.
+func unitWeight[K comparable](u, v K) float64 { return 1 }
+
+// WeightFunc returns the weights of the graph as a WeightFunc.
+func (g *WeightedUndirectedGraph) WeightFunc() WeightFunc[Node] {
+	return g.Weight
+}
+
+// NewGraphWeight returns a WeightFunc that reads the weight of an edge from the
+// given numeric attribute. Edges without the attribute have weight 1, and the
+// lightest of several parallel edges is used.
+func NewGraphWeight(g *NewGraph, attribute string) WeightFunc[string] {
+	return func(u, v string) float64 {
+		weight := math.Inf(1)
+		for _, key := range g.incidentEdges(u) {
+			edge := g.Edges[key]
+			forward := edge.First_node.ID == u && edge.Second_node.ID == v
+			backward := edge.First_node.ID == v && edge.Second_node.ID == u
+			if !forward && !(backward && !g.IsDirected()) {
+				continue
+			}
+			w, ok := toFloat(edge.Attributes[attribute])
+			if !ok {
+				w = 1
+			}
+			weight = min(weight, w)
+		}
+		return weight
+	}
+}
+
+// ShortestPaths holds the shortest paths from a source to every node that can
+// be reached from it, as a tree of predecessors.
+type ShortestPaths[K comparable] struct {
+	Source K
+	// Distances holds the length of the shortest path to every reachable node.
+	Distances map[K]float64
+	// Predecessors holds the node before every reachable node, other than the
+	// source, on its shortest path.
+	Predecessors map[K]K
+}
+
+func newShortestPaths[K comparable](source K) *ShortestPaths[K] {
+	return &ShortestPaths[K]{
+		Source:       source,
+		Distances:    map[K]float64{source: 0},
+		Predecessors: map[K]K{},
+	}
+}
+
+// DistanceTo returns the length of the shortest path to the target, and whether
+// the target can be reached.
+func (p *ShortestPaths[K]) DistanceTo(target K) (float64, bool) {
+	distance, ok := p.Distances[target]
+	return distance, ok
+}
+
+// PathTo returns the nodes of the shortest path from the source to the target,
+// including both, or nil if the target cannot be reached.
+func (p *ShortestPaths[K]) PathTo(target K) []K {
+	if _, ok := p.Distances[target]; !ok {
+		return nil
+	}
+	path := []K{target}
+	for node := target; node != p.Source; {
+		node = p.Predecessors[node]
+		path = append(path, node)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+func checkSource[K comparable](g GraphView[K], nodes ...K) error {
+	for _, node := range nodes {
+		if !g.HasNodeID(node) {
+			return fmt.Errorf("graph does not have node %v", node)
+		}
+	}
+	return nil
+}
+
+/*
+BFSShortestPaths returns the shortest paths from the source to every reachable node, measured in
+number of edges.
+
+Parameters:
+- g: The graph. Directed graphs are searched along the direction of their edges.
+- source: The node the paths start from.
+
+Returns:
+- *ShortestPaths[K]: The distances and the tree of predecessors.
+- error: An error if the graph does not have the source.
+
+Example:
+
+	paths, _ := BFSShortestPaths[Node](CycleGraph(6), 0)
+
+	fmt.Println(paths.Distances[3], paths.PathTo(2)) // Output: 3 [0 1 2]
+*/
+func BFSShortestPaths[K comparable](g GraphView[K], source K) (*ShortestPaths[K], error) {
+	if err := checkSource(g, source); err != nil {
+		return nil, err
+	}
+	paths := newShortestPaths(source)
+	queue := []K{source}
+	for head := 0; head < len(queue); head++ {
+		node := queue[head]
+		for _, neighbor := range g.NeighborIDs(node) {
+			if _, seen := paths.Distances[neighbor]; !seen {
+				paths.Distances[neighbor] = paths.Distances[node] + 1
+				paths.Predecessors[neighbor] = node
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+	return paths, nil
+}
+
+/*
+Dijkstra returns the shortest paths from the source to every reachable node in a graph with
+non-negative edge weights, using a binary heap.
+
+Parameters:
+- g: The graph. Directed graphs are searched along the direction of their edges.
+- source: The node the paths start from.
+- weight: The weight of every edge, or nil to give every edge weight 1.
+
+Returns:
+- *ShortestPaths[K]: The distances and the tree of predecessors.
+- error: An error if the graph does not have the source or has a negative weight.
+
+References:
+  - Dijkstra, E. W. (1959). A note on two problems in connexion with graphs. Numerische Mathematik, 1(1), 269-271.
+*/
+func Dijkstra[K comparable](g GraphView[K], source K, weight WeightFunc[K]) (*ShortestPaths[K], error) {
+	if err := checkSource(g, source); err != nil {
+		return nil, err
+	}
+	return bestFirstSearch(g, source, weight, nil, nil)
+}
+
+/*
+AStar returns a shortest path from the source to the target in a graph with non-negative edge
+weights, guided by a heuristic estimate of the remaining distance.
+
+Parameters:
+- g: The graph. Directed graphs are searched along the direction of their edges.
+- source, target: The endpoints of the path.
+- weight: The weight of every edge, or nil to give every edge weight 1.
+- heuristic: An estimate of the distance from a node to the target. The path is shortest if the
+heuristic never overestimates the distance; nil turns AStar into Dijkstra's algorithm.
+
+Returns:
+- []K: The nodes of the path, including the source and the target.
+- float64: The length of the path.
+- error: ErrNoPath if the target cannot be reached, or an error for a missing node or a negative weight.
+
+References:
+  - Hart, P. E., Nilsson, N. J., & Raphael, B. (1968). A formal basis for the heuristic determination of minimum cost paths. IEEE Transactions on Systems Science and Cybernetics, 4(2), 100-107.
+*/
+func AStar[K comparable](g GraphView[K], source, target K, weight WeightFunc[K], heuristic func(node K) float64) ([]K, float64, error) {
+	if err := checkSource(g, source, target); err != nil {
+		return nil, 0, err
+	}
+	paths, err := bestFirstSearch(g, source, weight, heuristic, &target)
+	if err != nil {
+		return nil, 0, err
+	}
+	distance, ok := paths.DistanceTo(target)
+	if !ok {
+		return nil, 0, ErrNoPath
+	}
+	return paths.PathTo(target), distance, nil
+}
+
+// ShortestPath returns a shortest path from the source to the target and its
+// length, using Dijkstra's algorithm that stops once the target is reached.
+func ShortestPath[K comparable](g GraphView[K], source, target K, weight WeightFunc[K]) ([]K, float64, error) {
+	return AStar(g, source, target, weight, nil)
+}
+
+// bestFirstSearch runs Dijkstra's algorithm, or A* if a heuristic is given. A
+// settled node whose distance improves later is expanded again, which only
+// happens with an inconsistent heuristic. If a target is given, it stops once
+// the target is settled, so the distances of other nodes may be upper bounds
+// only.
+func bestFirstSearch[K comparable](g GraphView[K], source K, weight WeightFunc[K], heuristic func(K) float64, target *K) (*ShortestPaths[K], error) {
+	if weight == nil {
+		weight = unitWeight[K]
+	}
+	if heuristic == nil {
+		heuristic = func(K) float64 { return 0 }
+	}
+	paths := newShortestPaths(source)
+	settled := make(map[K]bool)
+	queue := &searchQueue[K]{{node: source, priority: heuristic(source)}}
+	for queue.Len() > 0 {
+		node := heap.Pop(queue).(searchItem[K]).node
+		if settled[node] {
+			continue
+		}
+		settled[node] = true
+		if target != nil && node == *target {
+			break
+		}
+		for _, neighbor := range g.NeighborIDs(node) {
+			w := weight(node, neighbor)
+			if w < 0 {
+				return nil, fmt.Errorf("edge from %v to %v has negative weight %v", node, neighbor, w)
+			}
+			distance := paths.Distances[node] + w
+			if old, seen := paths.Distances[neighbor]; seen && old <= distance {
+				continue
+			}
+			paths.Distances[neighbor] = distance
+			paths.Predecessors[neighbor] = node
+			// an admissible but inconsistent heuristic can settle a node too early,
+			// so it is reopened to pass the shorter distance on
+			settled[neighbor] = false
+			heap.Push(queue, searchItem[K]{node: neighbor, priority: distance + heuristic(neighbor)})
+		}
+	}
+	return paths, nil
+}
+
+/*
+BellmanFord returns the shortest paths from the source to every reachable node in a graph whose edge
+weights may be negative.
+
+Parameters:
+- g: The graph. Directed graphs are searched along the direction of their edges. In undirected graphs,
+an edge with negative weight is a negative cycle.
+- source: The node the paths start from.
+- weight: The weight of every edge, or nil to give every edge weight 1.
+
+Returns:
+- *ShortestPaths[K]: The distances and the tree of predecessors.
+- error: ErrNegativeCycle if a negative cycle can be reached from the source, or an error if the
+graph does not have the source.
+
+References:
+  - Bellman, R. (1958). On a routing problem. Quarterly of Applied Mathematics, 16(1), 87-90.
+*/
+func BellmanFord[K comparable](g GraphView[K], source K, weight WeightFunc[K]) (*ShortestPaths[K], error) {
+	if err := checkSource(g, source); err != nil {
+		return nil, err
+	}
+	if weight == nil {
+		weight = unitWeight[K]
+	}
+	paths := newShortestPaths(source)
+	nodes := g.NodeIDs()
+	relax := func() bool {
+		changed := false
+		for _, node := range nodes {
+			distance, reached := paths.Distances[node]
+			if !reached {
+				continue
+			}
+			for _, neighbor := range g.NeighborIDs(node) {
+				candidate := distance + weight(node, neighbor)
+				if old, seen := paths.Distances[neighbor]; !seen || candidate < old {
+					paths.Distances[neighbor] = candidate
+					paths.Predecessors[neighbor] = node
+					changed = true
+				}
+			}
+		}
+		return changed
+	}
+	for i := 1; i < len(nodes); i++ {
+		if !relax() {
+			return paths, nil
+		}
+	}
+	if relax() {
+		return nil, ErrNegativeCycle
+	}
+	return paths, nil
+}
+
+type searchItem[K comparable] struct {
+	node     K
+	priority float64
+}
+
+// searchQueue is a min-heap of nodes by priority. Nodes may appear several
+// times; stale entries are skipped when popped.
+type searchQueue[K comparable] []searchItem[K]
+
+func (q searchQueue[K]) Len() int           { return len(q) }
+func (q searchQueue[K]) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q searchQueue[K]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *searchQueue[K]) Push(x any)        { *q = append(*q, x.(searchItem[K])) }
+func (q *searchQueue[K]) Pop() any {
+	old := *q
+	x := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return x
+}
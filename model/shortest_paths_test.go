@@ -0,0 +1,125 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestBFSShortestPaths(t *testing.T) {
+	paths, err := BFSShortestPaths[Node](CycleGraph(6), 0)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if paths.Distances[3] != 3 || !reflect.DeepEqual(paths.PathTo(2), []Node{0, 1, 2}) {
+		t.Errorf("Expected distance 3 and path [0 1 2], but got %v and %v", paths.Distances[3], paths.PathTo(2))
+	}
+
+	// Test case 2: Directed graphs are searched along their edges
+	d := &DirectedGraph{}
+	d.AddEdge(Edge{Node1: 1, Node2: 2})
+	d.AddEdge(Edge{Node1: 2, Node2: 3})
+	paths, _ = BFSShortestPaths[Node](d, 2)
+	if _, ok := paths.DistanceTo(1); ok || paths.PathTo(1) != nil || len(paths.PathTo(3)) != 2 {
+		t.Errorf("Expected 1 to be unreachable from 2, but got %v", paths.Distances)
+	}
+
+	if _, err := BFSShortestPaths[Node](d, 42); err == nil {
+		t.Errorf("Expected an error for a missing source")
+	}
+}
+
+func weightedDiamond() *WeightedUndirectedGraph {
+	// 0-1-3 is shorter than the direct edge 0-3, and 0-2-3 is longer
+	g := &WeightedUndirectedGraph{}
+	g.AddWeightedEdge(Edge{Node1: 0, Node2: 1}, 1)
+	g.AddWeightedEdge(Edge{Node1: 1, Node2: 3}, 2)
+	g.AddWeightedEdge(Edge{Node1: 0, Node2: 3}, 4)
+	g.AddWeightedEdge(Edge{Node1: 0, Node2: 2}, 2)
+	g.AddWeightedEdge(Edge{Node1: 2, Node2: 3}, 2.5)
+	g.AddNode(4)
+	return g
+}
+
+func TestDijkstraAndBellmanFord(t *testing.T) {
+	g := weightedDiamond()
+	for name, search := range map[string]func(GraphView[Node], Node, WeightFunc[Node]) (*ShortestPaths[Node], error){
+		"dijkstra":     Dijkstra[Node],
+		"bellman-ford": BellmanFord[Node],
+	} {
+		paths, err := search(g, 0, g.WeightFunc())
+		if err != nil {
+			t.Fatalf("%s: Expected no error, but got %v", name, err)
+		}
+		if paths.Distances[3] != 3 || !reflect.DeepEqual(paths.PathTo(3), []Node{0, 1, 3}) {
+			t.Errorf("%s: Expected distance 3 and path [0 1 3], but got %v and %v", name, paths.Distances[3], paths.PathTo(3))
+		}
+		if _, ok := paths.DistanceTo(4); ok {
+			t.Errorf("%s: Expected node 4 to be unreachable", name)
+		}
+	}
+
+	// Test case 2: Dijkstra rejects negative weights, Bellman-Ford detects negative cycles
+	g.AddWeightedEdge(Edge{Node1: 2, Node2: 3}, -1)
+	if _, err := Dijkstra[Node](g, 0, g.WeightFunc()); err == nil {
+		t.Errorf("Expected an error for a negative weight")
+	}
+	if _, err := BellmanFord[Node](g, 0, g.WeightFunc()); !errors.Is(err, ErrNegativeCycle) {
+		t.Errorf("Expected %v, but got %v", ErrNegativeCycle, err)
+	}
+
+	// Test case 3: Negative weights without a cycle in a directed graph
+	d := &DirectedGraph{}
+	for _, e := range []Edge{{Node1: 0, Node2: 1}, {Node1: 0, Node2: 2}, {Node1: 2, Node2: 1}} {
+		d.AddEdge(e)
+	}
+	weights := map[Edge]float64{{Node1: 0, Node2: 1}: 1, {Node1: 0, Node2: 2}: 2, {Node1: 2, Node2: 1}: -3}
+	paths, err := BellmanFord[Node](d, 0, func(u, v Node) float64 { return weights[Edge{Node1: u, Node2: v}] })
+	if err != nil || paths.Distances[1] != -1 || !reflect.DeepEqual(paths.PathTo(1), []Node{0, 2, 1}) {
+		t.Errorf("Expected distance -1 and path [0 2 1], but got %v and %v", paths.Distances[1], err)
+	}
+}
+
+func TestAStar(t *testing.T) {
+	// the heuristic is the Manhattan distance on a 5x5 grid, where node r*5+c is in row r and column c
+	grid := GridGraph(5, 5)
+	target := Node(24)
+	manhattan := func(n Node) float64 {
+		return math.Abs(float64(n/5-target/5)) + math.Abs(float64(n%5-target%5))
+	}
+	path, distance, err := AStar[Node](grid, 0, target, nil, manhattan)
+	if err != nil || distance != 8 || len(path) != 9 || path[0] != 0 || path[8] != target {
+		t.Errorf("Expected a path of length 8, but got %v (%v)", path, err)
+	}
+
+	// Test case 2: Unreachable targets and point-to-point search on a NewGraph
+	g := starNewGraph()
+	g.SetEdgeAttribute("a", "d", "weight", 5)
+	g.AddNode(newTestNode("x"))
+	if _, _, err := ShortestPath[string](&g, "a", "x", nil); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected %v, but got %v", ErrNoPath, err)
+	}
+	ids, distance, err := ShortestPath[string](&g, "b", "d", NewGraphWeight(&g, "weight"))
+	if err != nil || distance != 3 || !reflect.DeepEqual(ids, []string{"b", "a", "c", "d"}) {
+		t.Errorf("Expected path [b a c d] of length 3, but got %v and %v", ids, distance)
+	}
+
+	// Test case 3: Admissible but inconsistent heuristics
+	// h(1) = 4 settles 3 through 2 first; the shorter path through 1 reopens it
+	d := &DirectedGraph{}
+	weights := map[Edge]float64{{Node1: 0, Node2: 1}: 1, {Node1: 0, Node2: 2}: 1, {Node1: 1, Node2: 3}: 1, {Node1: 2, Node2: 3}: 3, {Node1: 3, Node2: 4}: 3}
+	for e := range weights {
+		d.AddEdge(e)
+	}
+	heuristic := func(n Node) float64 {
+		if n == 1 {
+			return 4
+		}
+		return 0
+	}
+	path, distance, err = AStar[Node](d, 0, 4, func(u, v Node) float64 { return weights[Edge{Node1: u, Node2: v}] }, heuristic)
+	if err != nil || distance != 5 || !reflect.DeepEqual(path, []Node{0, 1, 3, 4}) {
+		t.Errorf("Expected path [0 1 3 4] of length 5, but got %v and %v (%v)", path, distance, err)
+	}
+}
@@ -0,0 +1,393 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNotConverged is returned by the power-iteration centralities when the
+// scores still change by more than the tolerance after the maximum number of
+// iterations.
+var ErrNotConverged = errors.New("power iteration did not converge")
+
+// PowerIterationOptions configures the centralities computed by power
+// iteration. The zero value gives unweighted scores with the defaults below.
+type PowerIterationOptions[K comparable] struct {
+	// Tolerance stops the iteration once the L1 change of the scores drops below
+	// it, or 0 for 1e-6 per node.
+	Tolerance float64
+	// MaxIterations is the maximum number of iterations, or 0 for 1000.
+	MaxIterations int
+	// Weight gives the weight of every edge, or nil to give every edge weight 1.
+	Weight WeightFunc[K]
+}
+
+func (o PowerIterationOptions[K]) tolerance(n int) float64 {
+	if o.Tolerance <= 0 {
+		return 1e-6 * float64(n)
+	}
+	return o.Tolerance
+}
+
+func (o PowerIterationOptions[K]) maxIterations() int {
+	if o.MaxIterations <= 0 {
+		return 1000
+	}
+	return o.MaxIterations
+}
+
+// PageRankOptions configures PageRank. Start from DefaultPageRankOptions, as
+// the zero value has damping 0, for which the ranks equal the personalization.
+type PageRankOptions[K comparable] struct {
+	PowerIterationOptions[K]
+	// Damping is the probability of following an edge instead of jumping.
+	Damping float64
+	// Personalization gives the relative probability of jumping to every node,
+	// or nil to jump uniformly. Missing nodes get probability 0.
+	Personalization map[K]float64
+	// Dangling gives the relative probability of moving from a node without
+	// outgoing edges to every node, or nil to use the personalization.
+	Dangling map[K]float64
+}
+
+// DefaultPageRankOptions returns the usual options of PageRank: damping 0.85,
+// uniform jumps and no edge weights.
+func DefaultPageRankOptions[K comparable]() PageRankOptions[K] {
+	return PageRankOptions[K]{Damping: 0.85}
+}
+
+// distribution turns relative probabilities into a vector in the order of
+// the nodes, or the uniform distribution if weights is nil.
+func distribution[K comparable](nodes []K, weights map[K]float64, name string) ([]float64, error) {
+	p := make([]float64, len(nodes))
+	if weights == nil {
+		for i := range p {
+			p[i] = 1 / float64(len(nodes))
+		}
+		return p, nil
+	}
+	index := make(map[K]int, len(nodes))
+	for i, node := range nodes {
+		index[node] = i
+	}
+	total := 0.0
+	for node, weight := range weights {
+		i, ok := index[node]
+		if !ok {
+			return nil, fmt.Errorf("%s has node %v that is not in the graph", name, node)
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf("%s has negative value %v for node %v", name, weight, node)
+		}
+		p[i] = weight
+		total += weight
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("%s must have a positive value for some node", name)
+	}
+	for i := range p {
+		p[i] /= total
+	}
+	return p, nil
+}
+
+/*
+PageRank returns the PageRank of every node, the stationary distribution of a random walk that follows
+an edge with probability Damping and otherwise jumps to a node drawn from the personalization.
+
+Parameters:
+- g: The graph. Directed graphs are walked along the direction of their edges.
+- options: The damping factor, the personalization, the distribution used by nodes without outgoing
+edges, the edge weights and the stopping criteria.
+
+Returns:
+- map[K]float64: The rank of every node, summing to 1.
+- error: ErrNotConverged if the ranks did not converge, or an error for an invalid damping factor or
+personalization.
+
+References:
+  - Page, L., Brin, S., Motwani, R., & Winograd, T. (1999). The PageRank citation ranking: Bringing order to the web. Stanford InfoLab.
+*/
+func PageRank[K comparable](g GraphView[K], options PageRankOptions[K]) (map[K]float64, error) {
+	a := newIndexedAdjacency(g, options.Weight)
+	n := len(a.nodes)
+	if n == 0 {
+		return map[K]float64{}, nil
+	}
+	damping := options.Damping
+	if damping < 0 || damping > 1 {
+		return nil, fmt.Errorf("damping must be between 0 and 1, got %v", damping)
+	}
+	personalization, err := distribution(a.nodes, options.Personalization, "personalization")
+	if err != nil {
+		return nil, err
+	}
+	dangling := personalization
+	if options.Dangling != nil {
+		if dangling, err = distribution(a.nodes, options.Dangling, "dangling distribution"); err != nil {
+			return nil, err
+		}
+	}
+
+	outWeights := make([]float64, n)
+	for u, weights := range a.weights {
+		for _, w := range weights {
+			outWeights[u] += w
+		}
+	}
+
+	rank := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+	converged := powerIterate(&rank, &next, options.PowerIterationOptions, func(rank, next []float64) {
+		danglingRank := 0.0
+		for u := range rank {
+			if outWeights[u] == 0 {
+				danglingRank += rank[u]
+				continue
+			}
+			for k, v := range a.neighbors[u] {
+				next[v] += rank[u] * a.weights[u][k] / outWeights[u]
+			}
+		}
+		pageRankJumps(next, damping, danglingRank, personalization, dangling)
+	})
+	if !converged {
+		return nil, ErrNotConverged
+	}
+	return scoresByNode(a.nodes, rank), nil
+}
+
+// pageRankJumps completes a step of PageRank, in which next holds the rank
+// that reached every node along edges: it applies the damping, spreads the
+// rank of nodes without outgoing edges over dangling and adds the jumps drawn
+// from personalization.
+func pageRankJumps(next []float64, damping, danglingRank float64, personalization, dangling []float64) {
+	for v := range next {
+		next[v] = damping*(next[v]+danglingRank*dangling[v]) + (1-damping)*personalization[v]
+	}
+}
+
+// powerIterate repeatedly computes next from scores with step, which adds to
+// the zeroed next, and swaps the two until the L1 change drops below the
+// tolerance. It reports whether that happened within the maximum number of
+// iterations.
+func powerIterate[K comparable](scores, next *[]float64, options PowerIterationOptions[K], step func(scores, next []float64)) bool {
+	if len(*scores) == 0 {
+		return true
+	}
+	tolerance := options.tolerance(len(*scores))
+	for iteration := 0; iteration < options.maxIterations(); iteration++ {
+		for i := range *next {
+			(*next)[i] = 0
+		}
+		step(*scores, *next)
+		change := 0.0
+		for i := range *next {
+			change += math.Abs((*next)[i] - (*scores)[i])
+		}
+		*scores, *next = *next, *scores
+		if change < tolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize scales the scores to unit L2 norm, or to sum 1 with l1, and
+// reports whether they were non-zero.
+func normalize(scores []float64, l1 bool) bool {
+	norm := 0.0
+	for _, score := range scores {
+		if l1 {
+			norm += math.Abs(score)
+		} else {
+			norm += score * score
+		}
+	}
+	if !l1 {
+		norm = math.Sqrt(norm)
+	}
+	if norm == 0 {
+		return false
+	}
+	for i := range scores {
+		scores[i] /= norm
+	}
+	return true
+}
+
+func scoresByNode[K comparable](nodes []K, scores []float64) map[K]float64 {
+	result := make(map[K]float64, len(nodes))
+	for i, node := range nodes {
+		result[node] = scores[i]
+	}
+	return result
+}
+
+/*
+EigenvectorCentrality returns the eigenvector centrality of every node, its entry in the principal
+eigenvector of the adjacency matrix, so that a node is central if its neighbours are. The iteration
+uses the matrix plus the identity, which has the same eigenvectors but also converges on bipartite
+graphs.
+
+Parameters:
+- g: The graph. In directed graphs, a node is central if its predecessors are.
+- options: The edge weights and the stopping criteria.
+
+Returns:
+- map[K]float64: The centrality of every node, with unit Euclidean norm.
+- error: ErrNotConverged if the centralities did not converge.
+
+References:
+  - Bonacich, P. (1987). Power and centrality: A family of measures. American Journal of Sociology, 92(5), 1170-1182.
+*/
+func EigenvectorCentrality[K comparable](g GraphView[K], options PowerIterationOptions[K]) (map[K]float64, error) {
+	a := newIndexedAdjacency(g, options.Weight)
+	n := len(a.nodes)
+	scores := make([]float64, n)
+	for i := range scores {
+		scores[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+	converged := powerIterate(&scores, &next, options, func(scores, next []float64) {
+		copy(next, scores)
+		for u, neighbors := range a.neighbors {
+			for k, v := range neighbors {
+				next[v] += scores[u] * a.weights[u][k]
+			}
+		}
+		normalize(next, false)
+	})
+	if !converged {
+		return nil, ErrNotConverged
+	}
+	return scoresByNode(a.nodes, scores), nil
+}
+
+// KatzOptions configures KatzCentrality. Start from DefaultKatzOptions, as
+// the zero value gives every node centrality 0.
+type KatzOptions[K comparable] struct {
+	PowerIterationOptions[K]
+	// Alpha is the attenuation of every step. The iteration only converges if it
+	// is smaller than the reciprocal of the largest eigenvalue of the adjacency
+	// matrix.
+	Alpha float64
+	// Beta is the centrality every node gets by itself.
+	Beta float64
+	// Normalized scales the result to unit Euclidean norm.
+	Normalized bool
+}
+
+// DefaultKatzOptions returns the usual options of KatzCentrality: Alpha 0.1,
+// Beta 1, no normalisation and no edge weights.
+func DefaultKatzOptions[K comparable]() KatzOptions[K] {
+	return KatzOptions[K]{Alpha: 0.1, Beta: 1}
+}
+
+/*
+KatzCentrality returns the Katz centrality of every node, which counts the walks ending at it, the
+walks of length k weighted by Alpha^k: x = Alpha·Aᵀx + Beta.
+
+Parameters:
+- g: The graph. Directed graphs are walked along the direction of their edges.
+- options: The attenuation factor, the base centrality, the normalisation, the edge weights and the
+stopping criteria.
+
+Returns:
+- map[K]float64: The centrality of every node.
+- error: ErrNotConverged if the centralities did not converge, e.g. because Alpha is too large.
+
+References:
+  - Katz, L. (1953). A new status index derived from sociometric analysis. Psychometrika, 18(1), 39-43.
+*/
+func KatzCentrality[K comparable](g GraphView[K], options KatzOptions[K]) (map[K]float64, error) {
+	alpha, beta := options.Alpha, options.Beta
+	a := newIndexedAdjacency(g, options.Weight)
+	n := len(a.nodes)
+	scores := make([]float64, n)
+	next := make([]float64, n)
+	converged := powerIterate(&scores, &next, options.PowerIterationOptions, func(scores, next []float64) {
+		for u, neighbors := range a.neighbors {
+			for k, v := range neighbors {
+				next[v] += alpha * scores[u] * a.weights[u][k]
+			}
+		}
+		for v := range next {
+			next[v] += beta
+		}
+	})
+	if !converged {
+		return nil, ErrNotConverged
+	}
+	if options.Normalized {
+		normalize(scores, false)
+	}
+	return scoresByNode(a.nodes, scores), nil
+}
+
+/*
+HITS returns the hub and authority scores of every node. A good authority is pointed to by good hubs,
+and a good hub points to good authorities. In undirected graphs, both equal the eigenvector
+centrality up to scaling.
+
+Parameters:
+- g: The graph.
+- options: The edge weights and the stopping criteria, applied to the hub scores.
+
+Returns:
+- map[K]float64: The hub score of every node, summing to 1.
+- map[K]float64: The authority score of every node, summing to 1.
+- error: ErrNotConverged if the scores did not converge.
+
+References:
+  - Kleinberg, J. M. (1999). Authoritative sources in a hyperlinked environment. Journal of the ACM, 46(5), 604-632.
+*/
+func HITS[K comparable](g GraphView[K], options PowerIterationOptions[K]) (map[K]float64, map[K]float64, error) {
+	a := newIndexedAdjacency(g, options.Weight)
+	n := len(a.nodes)
+	hubs := make([]float64, n)
+	for i := range hubs {
+		hubs[i] = 1 / float64(n)
+	}
+	authorities := make([]float64, n)
+	next := make([]float64, n)
+	converged := powerIterate(&hubs, &next, options, func(hubs, next []float64) {
+		for i := range authorities {
+			authorities[i] = 0
+		}
+		for u, neighbors := range a.neighbors {
+			for k, v := range neighbors {
+				authorities[v] += hubs[u] * a.weights[u][k]
+			}
+		}
+		for u, neighbors := range a.neighbors {
+			for k, v := range neighbors {
+				next[u] += authorities[v] * a.weights[u][k]
+			}
+		}
+		if !normalize(next, true) {
+			// without edges, every node is as good a hub as any other
+			copy(next, hubs)
+		}
+	})
+	if !converged {
+		return nil, nil, ErrNotConverged
+	}
+
+	for i := range authorities {
+		authorities[i] = 0
+	}
+	for u, neighbors := range a.neighbors {
+		for k, v := range neighbors {
+			authorities[v] += hubs[u] * a.weights[u][k]
+		}
+	}
+	if !normalize(authorities, true) {
+		copy(authorities, hubs)
+	}
+	return scoresByNode(a.nodes, hubs), scoresByNode(a.nodes, authorities), nil
+}
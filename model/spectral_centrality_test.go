@@ -0,0 +1,130 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// precise makes the power iterations converge far below the precision of almostEqual.
+var precise = PowerIterationOptions[Node]{Tolerance: 1e-13}
+
+func TestPageRank(t *testing.T) {
+	rank, err := PageRank[Node](StarGraph(5), PageRankOptions[Node]{Damping: 0.85, PowerIterationOptions: precise})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	center := (1 - 0.85) / 5 * (1 + 4*0.85) / (1 - 0.85*0.85)
+	sum := 0.0
+	for _, r := range rank {
+		sum += r
+	}
+	if !almostEqual(rank[0], center) || !almostEqual(sum, 1) {
+		t.Errorf("Expected center rank %v and sum 1, but got %v and %v", center, rank[0], sum)
+	}
+
+	// Test case 2: Dangling nodes
+	// node 1 has no outgoing edge
+	d := &DirectedGraph{}
+	d.AddEdge(Edge{Node1: 0, Node2: 1})
+	rank, _ = PageRank[Node](d, PageRankOptions[Node]{Damping: 0.85, PowerIterationOptions: precise})
+	if !almostEqual(rank[0], 1/2.85) {
+		t.Errorf("Expected %v, but got %v", 1/2.85, rank[0])
+	}
+	rank, _ = PageRank[Node](d, PageRankOptions[Node]{Damping: 0.85, PowerIterationOptions: precise, Dangling: map[Node]float64{0: 1}})
+	if !almostEqual(rank[0], 0.5) {
+		t.Errorf("Expected 0.5, but got %v", rank[0])
+	}
+
+	// Test case 3: Personalization
+	path := PathGraph(3)
+	rank, _ = PageRank[Node](path, PageRankOptions[Node]{Damping: 0.85, PowerIterationOptions: precise, Personalization: map[Node]float64{0: 2}})
+	if rank[0] <= rank[2] || !almostEqual(rank[0]+rank[1]+rank[2], 1) {
+		t.Errorf("Expected node 0 to outrank node 2, but got %v", rank)
+	}
+	if _, err := PageRank[Node](path, PageRankOptions[Node]{Damping: 0.85, Personalization: map[Node]float64{7: 1}}); err == nil {
+		t.Errorf("Expected an error for a personalization of a missing node")
+	}
+	if _, err := PageRank[Node](path, PageRankOptions[Node]{Damping: 0.85, Personalization: map[Node]float64{0: 0}}); err == nil {
+		t.Errorf("Expected an error for an all-zero personalization")
+	}
+	// without damping, the walk only jumps
+	rank, _ = PageRank[Node](path, PageRankOptions[Node]{Personalization: map[Node]float64{0: 1, 2: 3}})
+	if rank[0] != 0.25 || rank[1] != 0 || rank[2] != 0.75 {
+		t.Errorf("Expected the personalization, but got %v", rank)
+	}
+	if _, err := PageRank[Node](path, PageRankOptions[Node]{Damping: 1.5}); err == nil {
+		t.Errorf("Expected an error for damping 1.5")
+	}
+	if defaults := DefaultPageRankOptions[Node](); defaults.Damping != 0.85 {
+		t.Errorf("Expected default damping 0.85, but got %v", defaults.Damping)
+	}
+
+	// Test case 4: CSRGraph.PageRank by node index
+	g := CycleGraph(6)
+	g.AddEdge(Edge{Node1: 0, Node2: 3})
+	csr := NewCSRGraph(g)
+	csrRank := csr.PageRank(0.85, 1e-13, 1000)
+	rank, _ = PageRank[Node](g, PageRankOptions[Node]{Damping: 0.85, PowerIterationOptions: precise})
+	for node := range g.Nodes {
+		if !almostEqual(rank[node], csrRank[csr.index[node]]) {
+			t.Errorf("Expected %v for node %d, but got %v", csrRank[csr.index[node]], node, rank[node])
+		}
+	}
+}
+
+func TestEigenvectorAndKatzCentrality(t *testing.T) {
+	eigenvector, err := EigenvectorCentrality[Node](StarGraph(5), precise)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !almostEqual(eigenvector[0], 1/math.Sqrt(2)) || !almostEqual(eigenvector[3], 1/math.Sqrt(8)) {
+		t.Errorf("Expected %v and %v, but got %v and %v", 1/math.Sqrt(2), 1/math.Sqrt(8), eigenvector[0], eigenvector[3])
+	}
+
+	katz, err := KatzCentrality[Node](PathGraph(3), KatzOptions[Node]{Alpha: 0.1, Beta: 1, PowerIterationOptions: precise})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	// x0 = 1 + 0.1·x1 and x1 = 1 + 0.2·x0
+	if !almostEqual(katz[0], 1.1/0.98) || !almostEqual(katz[1], 1+0.2*1.1/0.98) {
+		t.Errorf("Expected %v and %v, but got %v and %v", 1.1/0.98, 1+0.2*1.1/0.98, katz[0], katz[1])
+	}
+	katz, _ = KatzCentrality[Node](PathGraph(3), KatzOptions[Node]{Alpha: 0.1, Beta: 1, PowerIterationOptions: precise, Normalized: true})
+	if !almostEqual(katz[0]*katz[0]+katz[1]*katz[1]+katz[2]*katz[2], 1) {
+		t.Errorf("Expected unit norm, but got %v", katz)
+	}
+
+	// Beta 0 gives every node centrality 0
+	zero := DefaultKatzOptions[Node]()
+	zero.Beta = 0
+	if katz, _ := KatzCentrality[Node](PathGraph(3), zero); katz[1] != 0 {
+		t.Errorf("Expected 0, but got %v", katz[1])
+	}
+
+	// Test case 2: Alpha beyond the reciprocal of the largest eigenvalue
+	if _, err := KatzCentrality[Node](CycleGraph(4), KatzOptions[Node]{Alpha: 1, Beta: 1}); !errors.Is(err, ErrNotConverged) {
+		t.Errorf("Expected %v, but got %v", ErrNotConverged, err)
+	}
+}
+
+func TestHITS(t *testing.T) {
+	d := &DirectedGraph{}
+	d.AddEdge(Edge{Node1: 0, Node2: 2})
+	d.AddEdge(Edge{Node1: 1, Node2: 2})
+	hubs, authorities, err := HITS[Node](d, precise)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !almostEqual(hubs[0], 0.5) || hubs[2] != 0 || !almostEqual(authorities[2], 1) || authorities[0] != 0 {
+		t.Errorf("Expected hubs 0 and 1 and authority 2, but got %v and %v", hubs, authorities)
+	}
+
+	// Test case 2: Graphs without edges
+	g := &UndirectedGraph{}
+	g.AddNodes([]Node{0, 1})
+	hubs, authorities, _ = HITS[Node](g, precise)
+	if hubs[0] != 0.5 || authorities[1] != 0.5 {
+		t.Errorf("Expected uniform scores, but got %v and %v", hubs, authorities)
+	}
+}
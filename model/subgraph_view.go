@@ -0,0 +1,166 @@
+package model
+
+// SubgraphView is a read-only view of part of a graph. It keeps a reference to
+// the parent graph and filters its nodes and edges on the fly, so creating a
+// view copies nothing and changes to the parent are visible through it.
+type SubgraphView[K comparable] struct {
+	parent     GraphView[K]
+	nodeFilter func(id K) bool
+	edgeFilter func(u, v K) bool
+}
+
+var _ GraphView[Node] = (*SubgraphView[Node])(nil)
+
+// FilteredView returns a view of the nodes of parent for which nodeFilter is
+// true and the edges between them for which edgeFilter is true. A nil filter
+// keeps everything. In undirected graphs, edgeFilter must be symmetric.
+func FilteredView[K comparable](parent GraphView[K], nodeFilter func(id K) bool, edgeFilter func(u, v K) bool) *SubgraphView[K] {
+	if nodeFilter == nil {
+		nodeFilter = func(K) bool { return true }
+	}
+	if edgeFilter == nil {
+		edgeFilter = func(K, K) bool { return true }
+	}
+	return &SubgraphView[K]{parent: parent, nodeFilter: nodeFilter, edgeFilter: edgeFilter}
+}
+
+// InducedSubgraph returns a view of the given nodes of parent and all edges
+// between them. Nodes that parent does not contain are ignored.
+func InducedSubgraph[K comparable](parent GraphView[K], nodes []K) *SubgraphView[K] {
+	set := make(map[K]bool, len(nodes))
+	for _, node := range nodes {
+		set[node] = true
+	}
+	return FilteredView(parent, func(id K) bool { return set[id] }, nil)
+}
+
+// EdgeSubgraph returns a view of the given edges of parent and their nodes.
+// Edges that parent does not contain are ignored. In a multigraph, all
+// parallel edges between the nodes of a given edge are kept.
+func EdgeSubgraph[K comparable](parent GraphView[K], edges [][2]K) *SubgraphView[K] {
+	nodes := make(map[K]bool, 2*len(edges))
+	pairs := make(map[[2]K]bool, 2*len(edges))
+	directed := parent.IsDirected()
+	for _, edge := range edges {
+		nodes[edge[0]] = true
+		nodes[edge[1]] = true
+		pairs[edge] = true
+		if !directed {
+			pairs[[2]K{edge[1], edge[0]}] = true
+		}
+	}
+	return FilteredView(parent,
+		func(id K) bool { return nodes[id] },
+		func(u, v K) bool { return pairs[[2]K{u, v}] },
+	)
+}
+
+// ComponentViews returns a view of every connected component of the graph,
+// largest first.
+func ComponentViews[K comparable](g GraphView[K]) []*SubgraphView[K] {
+	components := ComponentsOf(g)
+	views := make([]*SubgraphView[K], len(components))
+	for i, component := range components {
+		views[i] = InducedSubgraph(g, component)
+	}
+	return views
+}
+
+// SampleView runs a sampling strategy on the graph and returns a view of the
+// subgraph induced by the sampled nodes.
+func SampleView[K comparable](g GraphView[K], sampler ISamplingStrategy, sampledGraphSizeRatio float32) (*SubgraphView[K], error) {
+	nodes, err := SampleNodes(g, sampler, sampledGraphSizeRatio)
+	if err != nil {
+		return nil, err
+	}
+	return InducedSubgraph(g, nodes), nil
+}
+
+// Parent returns the graph the view was created from.
+func (v *SubgraphView[K]) Parent() GraphView[K] {
+	return v.parent
+}
+
+func (v *SubgraphView[K]) NodeIDs() []K {
+	ids := []K{}
+	for _, id := range v.parent.NodeIDs() {
+		if v.nodeFilter(id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (v *SubgraphView[K]) HasNodeID(id K) bool {
+	return v.parent.HasNodeID(id) && v.nodeFilter(id)
+}
+
+func (v *SubgraphView[K]) NeighborIDs(id K) []K {
+	if !v.HasNodeID(id) {
+		return nil
+	}
+	neighbors := []K{}
+	for _, neighbor := range v.parent.NeighborIDs(id) {
+		if v.nodeFilter(neighbor) && v.edgeFilter(id, neighbor) {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	return neighbors
+}
+
+func (v *SubgraphView[K]) NumberOfNodes() int {
+	return len(v.NodeIDs())
+}
+
+// NumberOfEdges counts the edges of the view. As the view is not
+// materialised, this takes time linear in the size of the parent.
+func (v *SubgraphView[K]) NumberOfEdges() int {
+	entries, loops := 0, 0
+	for _, id := range v.NodeIDs() {
+		for _, neighbor := range v.NeighborIDs(id) {
+			if neighbor == id {
+				loops++
+			} else {
+				entries++
+			}
+		}
+	}
+	if v.IsDirected() {
+		return entries + loops
+	}
+	return entries/2 + loops
+}
+
+func (v *SubgraphView[K]) IsDirected() bool {
+	return v.parent.IsDirected()
+}
+
+// NodeAttributes returns the attributes of the node in the parent graph, or
+// nil if the node is not in the view or the parent has no attributes.
+func (v *SubgraphView[K]) NodeAttributes(id K) map[string]interface{} {
+	attributed, ok := v.parent.(interface {
+		NodeAttributes(id K) map[string]interface{}
+	})
+	if !ok || !v.HasNodeID(id) {
+		return nil
+	}
+	return attributed.NodeAttributes(id)
+}
+
+// EdgeAttributes returns the attributes of the edge in the parent graph, or
+// nil if the edge is not in the view or the parent has no attributes.
+func (v *SubgraphView[K]) EdgeAttributes(a, b K) map[string]interface{} {
+	attributed, ok := v.parent.(interface {
+		EdgeAttributes(u, v K) map[string]interface{}
+	})
+	if !ok || !v.HasNodeID(a) || !v.HasNodeID(b) || !v.edgeFilter(a, b) {
+		return nil
+	}
+	return attributed.EdgeAttributes(a, b)
+}
+
+// Materialize copies the nodes and edges of the view into dst, e.g. to modify
+// them without affecting the parent.
+func (v *SubgraphView[K]) Materialize(dst MutableGraph[K]) {
+	CopyGraph[K, K](dst, v, func(id K) K { return id })
+}
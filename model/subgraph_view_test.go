@@ -0,0 +1,54 @@
+package model
+
+import (
+	"sort"
+	"testing"
+)
+
+func sortedNodes(nodes []Node) []Node {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+	return nodes
+}
+
+func TestInducedSubgraph(t *testing.T) {
+	g := twoCliques()
+	view := InducedSubgraph[Node](g, []Node{3, 4, 5, 6, 42})
+
+	if view.NumberOfNodes() != 4 || view.NumberOfEdges() != 3 {
+		t.Errorf("Expected 4 nodes and 3 edges, but got %d and %d", view.NumberOfNodes(), view.NumberOfEdges())
+	}
+	if neighbors := sortedNodes(view.NeighborIDs(4)); len(neighbors) != 2 || neighbors[0] != 3 || neighbors[1] != 5 {
+		t.Errorf("Expected neighbors [3 5], but got %v", neighbors)
+	}
+
+	// Test case 2: The view shares storage with the parent
+	g.RemoveEdge(Edge{Node1: 4, Node2: 5})
+	if view.NumberOfEdges() != 2 || view.HasNodeID(42) {
+		t.Errorf("Expected the view to follow the parent, got %d edges", view.NumberOfEdges())
+	}
+
+	materialized := &UndirectedGraph{}
+	view.Materialize(materialized)
+	if materialized.NumberOfNodes() != 4 || materialized.NumberOfEdges() != 2 {
+		t.Errorf("Expected 4 nodes and 2 edges, but got %d and %d", materialized.NumberOfNodes(), materialized.NumberOfEdges())
+	}
+}
+
+func TestEdgeSubgraphAndFilteredView(t *testing.T) {
+	g := starNewGraph()
+	g.SetEdgeAttribute("a", "c", "weight", 2)
+
+	view := EdgeSubgraph[string](&g, [][2]string{{"c", "a"}, {"c", "d"}})
+	if view.NumberOfNodes() != 3 || view.NumberOfEdges() != 2 || view.HasNodeID("b") {
+		t.Errorf("Expected nodes a, c, d and 2 edges, but got %v", view.NodeIDs())
+	}
+	if view.EdgeAttributes("a", "c")["weight"] != 2 || view.EdgeAttributes("a", "d") != nil {
+		t.Errorf("Expected the attributes of a-c only, got %v", view.EdgeAttributes("a", "c"))
+	}
+
+	// a view without the center of the star
+	filtered := FilteredView[string](&g, func(id string) bool { return id != "a" }, nil)
+	if components := ComponentViews[string](filtered); len(components) != 2 || components[0].NumberOfNodes() != 2 {
+		t.Errorf("Expected components of sizes 2 and 1, but got %d", len(components))
+	}
+}
@@ -0,0 +1,208 @@
+package model
+
+// WeightedUndirectedGraph is an UndirectedGraph with a float64 weight on every
+// edge. The adjacency lists of the embedded UndirectedGraph hold every
+// neighbour once, while the weights are kept symmetric in Weights. Weighted
+// self-loops are always allowed, as they hold the internal weight of
+// aggregated communities.
+type WeightedUndirectedGraph struct {
+	UndirectedGraph
+	Weights map[Node]map[Node]float64
+}
+
+var _ Graph = (*WeightedUndirectedGraph)(nil)
+
+func (g *WeightedUndirectedGraph) setWeight(u, v Node, weight float64) {
+	if g.Weights == nil {
+		g.Weights = make(map[Node]map[Node]float64)
+	}
+	if g.Weights[u] == nil {
+		g.Weights[u] = make(map[Node]float64)
+	}
+	if g.Weights[v] == nil {
+		g.Weights[v] = make(map[Node]float64)
+	}
+	g.Weights[u][v] = weight
+	g.Weights[v][u] = weight
+}
+
+/*
+AddWeightedEdge adds an undirected edge with the given weight to the WeightedUndirectedGraph.
+
+Parameters:
+- edge: An Edge struct representing the edge to be added, with Node1 and Node2 as the connected nodes.
+- weight: The weight of the edge.
+
+Description:
+If the two nodes are not yet connected, the edge is added to the adjacency lists and both nodes are added to the graph if they do not already exist. If they are already connected, only the weight of the existing edge is replaced.
+
+Example:
+
+	weightedGraph := WeightedUndirectedGraph{}
+	weightedGraph.AddWeightedEdge(Edge{Node1: 1, Node2: 2}, 0.5)
+
+	fmt.Println(weightedGraph.Weight(2, 1)) // Output: 0.5
+*/
+func (g *WeightedUndirectedGraph) AddWeightedEdge(edge Edge, weight float64) {
+	if _, exists := g.Weights[edge.Node1][edge.Node2]; !exists && !containsNode(g.Edges[edge.Node1], edge.Node2) {
+		g.appendEdge(edge)
+	}
+	g.setWeight(edge.Node1, edge.Node2, weight)
+}
+
+// AddEdge adds an edge with weight 1, or leaves an existing edge unchanged.
+func (g *WeightedUndirectedGraph) AddEdge(edge Edge) {
+	if g.HasWeightedEdge(edge.Node1, edge.Node2) {
+		return
+	}
+	g.AddWeightedEdge(edge, 1)
+}
+
+// HasWeightedEdge reports whether the nodes u and v are connected.
+func (g *WeightedUndirectedGraph) HasWeightedEdge(u, v Node) bool {
+	if _, exists := g.Weights[u][v]; exists {
+		return true
+	}
+	return containsNode(g.Edges[u], v)
+}
+
+// Weight returns the weight of the edge between u and v, or 0 if they are not
+// connected. Edges that were added through the embedded UndirectedGraph without
+// a weight count as weight 1 per parallel edge.
+func (g *WeightedUndirectedGraph) Weight(u, v Node) float64 {
+	if weight, exists := g.Weights[u][v]; exists {
+		return weight
+	}
+	count := 0
+	for _, neighbor := range g.Edges[u] {
+		if neighbor == v {
+			count++
+		}
+	}
+	if u == v {
+		// a self-loop appears twice in the adjacency list of its node
+		count /= 2
+	}
+	return float64(count)
+}
+
+// Strength returns the weighted degree of the node, the sum of the weights of
+// its incident edges. Self-loops count twice, as they do for NodeDegree.
+func (g *WeightedUndirectedGraph) Strength(node Node) float64 {
+	strength := 0.0
+	seen := make(map[Node]bool, len(g.Edges[node]))
+	for _, neighbor := range g.Edges[node] {
+		if seen[neighbor] {
+			continue
+		}
+		seen[neighbor] = true
+		weight := g.Weight(node, neighbor)
+		if neighbor == node {
+			weight *= 2
+		}
+		strength += weight
+	}
+	return strength
+}
+
+// TotalWeight returns the sum of the weights of all edges in the graph.
+func (g *WeightedUndirectedGraph) TotalWeight() float64 {
+	total := 0.0
+	for node := range g.Nodes {
+		total += g.Strength(node)
+	}
+	return total / 2
+}
+
+// RemoveEdge removes the edge between edge.Node1 and edge.Node2 together with its weight.
+func (g *WeightedUndirectedGraph) RemoveEdge(edge Edge) {
+	g.UndirectedGraph.RemoveEdge(edge)
+	delete(g.Weights[edge.Node1], edge.Node2)
+	delete(g.Weights[edge.Node2], edge.Node1)
+}
+
+// RemoveNode removes the node, its edges and their weights.
+func (g *WeightedUndirectedGraph) RemoveNode(node Node) {
+	for neighbor := range g.Weights[node] {
+		delete(g.Weights[neighbor], node)
+	}
+	delete(g.Weights, node)
+	g.UndirectedGraph.RemoveNode(node)
+}
+
+// RemoveNodes removes the nodes, their edges and their weights.
+func (g *WeightedUndirectedGraph) RemoveNodes(nodes []Node) {
+	for _, node := range nodes {
+		for neighbor := range g.Weights[node] {
+			delete(g.Weights[neighbor], node)
+		}
+		delete(g.Weights, node)
+	}
+	g.UndirectedGraph.RemoveNodes(nodes)
+}
+
+// RemoveEdges removes the edges and their weights.
+func (g *WeightedUndirectedGraph) RemoveEdges(edges []Edge) {
+	g.UndirectedGraph.RemoveEdges(edges)
+	for _, edge := range edges {
+		delete(g.Weights[edge.Node1], edge.Node2)
+		delete(g.Weights[edge.Node2], edge.Node1)
+	}
+}
+
+// ContractNode removes the node and connects every pair of its neighbours, as
+// UndirectedGraph.ContractNode does. A new edge between two neighbours gets
+// the smaller of the two weights it replaces, the strength of the path through
+// the node, while edges that already exist keep their weights.
+func (g *WeightedUndirectedGraph) ContractNode(node Node) {
+	neighbors := g.distinctNeighbors(node)
+	for i := 0; i < len(neighbors); i++ {
+		for j := i + 1; j < len(neighbors); j++ {
+			u, v := neighbors[i], neighbors[j]
+			if u == node || v == node || g.HasWeightedEdge(u, v) {
+				continue
+			}
+			g.AddWeightedEdge(Edge{Node1: u, Node2: v}, min(g.Weight(u, node), g.Weight(node, v)))
+		}
+	}
+	g.RemoveNode(node)
+}
+
+// ContractEdge merges edge.Node1 into edge.Node2, as UndirectedGraph.ContractEdge
+// does. The weight of every edge of Node1 is added to the edge between Node2
+// and the same neighbour, so the contracted edge and self-loops of Node1 add up
+// in a self-loop of Node2. The self-loop is kept even if DisallowSelfLoops is
+// set, as it holds the internal weight of the merged nodes.
+func (g *WeightedUndirectedGraph) ContractEdge(edge Edge) {
+	node1, node2 := edge.Node1, edge.Node2
+	for _, neighbor := range g.distinctNeighbors(node1) {
+		weight := g.Weight(node1, neighbor)
+		if neighbor == node1 {
+			neighbor = node2
+		}
+		g.AddWeightedEdge(Edge{Node1: node2, Node2: neighbor}, g.Weight(node2, neighbor)+weight)
+	}
+	g.RemoveNode(node1)
+}
+
+// distinctNeighbors returns the neighbours of the node, each once.
+func (g *WeightedUndirectedGraph) distinctNeighbors(node Node) []Node {
+	neighbors := []Node{}
+	seen := make(map[Node]bool, len(g.Edges[node]))
+	for _, neighbor := range g.Edges[node] {
+		if !seen[neighbor] {
+			seen[neighbor] = true
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	return neighbors
+}
+
+func containsNode(nodes []Node, node Node) bool {
+	for _, n := range nodes {
+		if n == node {
+			return true
+		}
+	}
+	return false
+}
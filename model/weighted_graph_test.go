@@ -0,0 +1,118 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWeightedUndirectedGraph_AddWeightedEdge(t *testing.T) {
+	g := WeightedUndirectedGraph{}
+	g.AddWeightedEdge(Edge{Node1: 1, Node2: 2}, 0.5)
+	g.AddWeightedEdge(Edge{Node1: 2, Node2: 3}, 2)
+
+	// Test case 1: Re-adding an edge replaces the weight without duplicating it
+	g.AddWeightedEdge(Edge{Node1: 2, Node2: 1}, 1.5)
+
+	expectedEdges := map[Node][]Node{1: {2}, 2: {1, 3}, 3: {2}}
+	if !reflect.DeepEqual(g.Edges, expectedEdges) {
+		t.Errorf("Expected %v, but got %v", expectedEdges, g.Edges)
+	}
+	if g.Weight(1, 2) != 1.5 || g.Weight(2, 1) != 1.5 {
+		t.Errorf("Expected weight 1.5, but got %v and %v", g.Weight(1, 2), g.Weight(2, 1))
+	}
+	if g.Strength(2) != 3.5 {
+		t.Errorf("Expected strength 3.5, but got %v", g.Strength(2))
+	}
+	if g.TotalWeight() != 3.5 {
+		t.Errorf("Expected total weight 3.5, but got %v", g.TotalWeight())
+	}
+
+	// Test case 2: Missing edges have weight 0, unweighted ones weight 1
+	if g.Weight(1, 3) != 0 {
+		t.Errorf("Expected weight 0, but got %v", g.Weight(1, 3))
+	}
+	g.AddEdge(Edge{Node1: 3, Node2: 4})
+	if g.Weight(4, 3) != 1 {
+		t.Errorf("Expected weight 1, but got %v", g.Weight(4, 3))
+	}
+
+	// Test case 3: Self-loops count twice in the strength
+	g.AddWeightedEdge(Edge{Node1: 4, Node2: 4}, 2)
+	if g.Strength(4) != 5 {
+		t.Errorf("Expected strength 5, but got %v", g.Strength(4))
+	}
+}
+
+func TestWeightedUndirectedGraph_Remove(t *testing.T) {
+	g := WeightedUndirectedGraph{}
+	g.AddWeightedEdge(Edge{Node1: 1, Node2: 2}, 0.5)
+	g.AddWeightedEdge(Edge{Node1: 2, Node2: 3}, 2)
+
+	g.RemoveEdge(Edge{Node1: 1, Node2: 2})
+	if g.Weight(1, 2) != 0 || g.HasWeightedEdge(2, 1) {
+		t.Errorf("Expected edge 1-2 to be removed")
+	}
+
+	g.RemoveNode(3)
+	if g.HasNode(3) || len(g.Weights[2]) != 0 || g.Strength(2) != 0 {
+		t.Errorf("Expected node 3 and its weights to be removed, got %v", g.Weights)
+	}
+}
+
+func TestWeightedUndirectedGraph_Contract(t *testing.T) {
+	g := &WeightedUndirectedGraph{}
+	g.AddWeightedEdge(Edge{Node1: 0, Node2: 1}, 5)
+	g.AddWeightedEdge(Edge{Node1: 1, Node2: 2}, 7)
+	g.AddWeightedEdge(Edge{Node1: 0, Node2: 2}, 1)
+
+	g.ContractEdge(Edge{Node1: 0, Node2: 1})
+	if g.HasNode(0) || g.HasWeightedEdge(0, 1) || len(g.Weights[0]) != 0 {
+		t.Errorf("Expected node 0 and its weights to be removed, got %v", g.Weights)
+	}
+	// the edge 0-2 adds to 1-2, and the contracted edge becomes a self-loop
+	if g.Weight(1, 2) != 8 || g.Weight(2, 1) != 8 || g.Weight(1, 1) != 5 {
+		t.Errorf("Expected weight 8 and a self-loop of weight 5, but got %v", g.Weights)
+	}
+
+	// Test case 2: The self-loop is kept whatever the adjacency policy
+	loops := &WeightedUndirectedGraph{UndirectedGraph: *NewSimpleGraph()}
+	loops.AddWeightedEdge(Edge{Node1: 0, Node2: 1}, 5)
+	loops.ContractEdge(Edge{Node1: 0, Node2: 1})
+	if loops.Weight(1, 1) != 5 || loops.NumberOfEdges() != 1 {
+		t.Errorf("Expected a self-loop of weight 5, but got %v", loops.Weights)
+	}
+
+	// Test case 3: Contracting a node
+	path := &WeightedUndirectedGraph{}
+	path.AddWeightedEdge(Edge{Node1: 0, Node2: 1}, 5)
+	path.AddWeightedEdge(Edge{Node1: 1, Node2: 2}, 7)
+	path.ContractNode(1)
+	if path.HasNode(1) || len(path.Weights[1]) != 0 || path.Weight(0, 2) != 5 || path.NumberOfEdges() != 1 {
+		t.Errorf("Expected a single edge 0-2 of weight 5, but got %v", path.Weights)
+	}
+}
+
+func TestWeightedCommunities(t *testing.T) {
+	// Two heavy edges joined by a light one
+	g := &WeightedUndirectedGraph{}
+	g.AddWeightedEdge(Edge{Node1: 0, Node2: 1}, 10)
+	g.AddWeightedEdge(Edge{Node1: 1, Node2: 2}, 1)
+	g.AddWeightedEdge(Edge{Node1: 2, Node2: 3}, 10)
+
+	expected := 2 * (10.0/21.0 - 0.25)
+	for name, detect := range map[string]func(*WeightedUndirectedGraph, float64, int64) (Partition, float64){
+		"Louvain": WeightedLouvain,
+		"Leiden":  WeightedLeiden,
+	} {
+		partition, modularity := detect(g, 1, 1)
+		if partition[0] != partition[1] || partition[2] != partition[3] || partition[1] == partition[2] {
+			t.Errorf("%s: expected communities {0 1} and {2 3}, but got %v", name, partition)
+		}
+		if !almostEqual(modularity, expected) {
+			t.Errorf("%s: expected modularity %v, but got %v", name, expected, modularity)
+		}
+		if result := WeightedModularity(g, partition, 1); !almostEqual(result, expected) {
+			t.Errorf("%s: expected weighted modularity %v, but got %v", name, expected, result)
+		}
+	}
+}